@@ -9,10 +9,15 @@ require (
 	github.com/bww/go-util v1.47.0
 	github.com/hashicorp/golang-lru/v2 v2.0.2
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/net v0.34.0
+	golang.org/x/tools v0.29.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,187 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/bww/epl/v1"
+	"github.com/bww/go-validate/v1/stdlib"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// CacheConfig describes the capacity of the caches used by a validator. A
+// size of zero or less disables the corresponding cache.
+type CacheConfig struct {
+	ExprSize    int // the maximum number of compiled expressions
+	TypeSize    int // the maximum number of struct type descriptions
+	PatternSize int // the maximum number of compiled patterns used by str.Match
+}
+
+// Cache holds compiled expressions, struct type descriptions and regular
+// expressions so they don't need to be recreated every time a value is
+// validated. A cache is safe for concurrent use and may be shared by any
+// number of validators.
+type Cache struct {
+	exprs    *lruCache[string, *epl.Program]
+	types    *lruCache[typeKey, *validatedType]
+	patterns *lruCache[string, *regexp.Regexp]
+	str      stdlib.Strings // the str namespace, which compiles patterns by way of this cache
+}
+
+// NewCache creates a new cache with the provided configuration.
+func NewCache(conf CacheConfig) *Cache {
+	c := &Cache{
+		exprs:    newLRUCache[string, *epl.Program](conf.ExprSize),
+		types:    newLRUCache[typeKey, *validatedType](conf.TypeSize),
+		patterns: newLRUCache[string, *regexp.Regexp](conf.PatternSize),
+	}
+	c.str = stdlib.Strings{Compile: func(src string) (*regexp.Regexp, error) { // bind once, since a closure allocates
+		return c.pattern(src, nil)
+	}}
+	return c
+}
+
+// SharedCache returns the cache used by validators which have not been
+// configured with a cache of their own. Its capacity is determined by the
+// environment variables GO_VALIDATE_EXPR_CACHE_SIZE,
+// GO_VALIDATE_TYPE_CACHE_SIZE and GO_VALIDATE_PATTERN_CACHE_SIZE.
+func SharedCache() *Cache {
+	return sharedCache
+}
+
+// Cache returns the cache used by the validator.
+func (v Validator) Cache() *Cache {
+	if v.cache != nil {
+		return v.cache
+	} else {
+		return sharedCache
+	}
+}
+
+// Strings returns the str namespace as it is available to the validator's
+// expressions, which compiles patterns by way of the validator's cache.
+// Generated validators use it in place of a zero [stdlib.Strings]; since
+// generated code is not used while validation is observed, the patterns it
+// compiles are not reported to observers.
+func (v Validator) Strings() stdlib.Strings {
+	return v.Cache().str
+}
+
+// CacheStats describes the state of a cache.
+type CacheStats struct {
+	Exprs    CacheCounters
+	Types    CacheCounters
+	Patterns CacheCounters
+}
+
+// CacheCounters describes the state of one of the caches that make up a
+// [Cache]. Counters are accumulated since the cache was created or last
+// reset.
+type CacheCounters struct {
+	Size      int // the capacity of the cache; zero if it is disabled
+	Len       int // the number of entries in the cache
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// Stats returns statistics describing the cache.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Exprs:    c.exprs.Stats(),
+		Types:    c.types.Stats(),
+		Patterns: c.patterns.Stats(),
+	}
+}
+
+// Reset removes every entry from the cache and resets its counters.
+func (c *Cache) Reset() {
+	c.exprs.Reset()
+	c.types.Reset()
+	c.patterns.Reset()
+}
+
+// pattern obtains the compiled regular expression for the provided
+// pattern, from the cache if possible.
+func (c *Cache) pattern(src string, errs *errorBuffer) (*regexp.Regexp, error) {
+	if c.patterns != nil {
+		re, ok := c.patterns.Get(src)
+		errs.cached(PatternCache, ok)
+		if ok {
+			return re, nil
+		}
+	}
+	re, err := regexp.Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	c.patterns.Add(src, re)
+	return re, nil
+}
+
+// lruCache is an LRU cache which counts its hits, misses and evictions.
+// A nil cache is disabled: it never contains anything.
+type lruCache[K comparable, V any] struct {
+	lru                     *lru.Cache[K, V]
+	size                    int
+	hits, misses, evictions atomic.Uint64
+}
+
+func newLRUCache[K comparable, V any](size int) *lruCache[K, V] {
+	if size <= 0 {
+		return nil
+	}
+	c := &lruCache[K, V]{size: size}
+	var err error
+	c.lru, err = lru.NewWithEvict[K, V](size, func(K, V) {
+		c.evictions.Add(1)
+	}) // in practice this cannot fail because we've checked that size > 0
+	if err != nil {
+		panic(fmt.Errorf("validate: Could not create cache: %v", err))
+	}
+	return c
+}
+
+func (c *lruCache[K, V]) Get(k K) (V, bool) {
+	if c == nil {
+		var z V
+		return z, false
+	}
+	v, ok := c.lru.Get(k)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return v, ok
+}
+
+func (c *lruCache[K, V]) Add(k K, v V) {
+	if c != nil {
+		c.lru.Add(k, v)
+	}
+}
+
+func (c *lruCache[K, V]) Stats() CacheCounters {
+	if c == nil {
+		return CacheCounters{}
+	}
+	return CacheCounters{
+		Size:      c.size,
+		Len:       c.lru.Len(),
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+func (c *lruCache[K, V]) Reset() {
+	if c == nil {
+		return
+	}
+	c.lru.Purge()
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+}
@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cacheP struct {
+	F1 string `json:"p_1" check:"str.Match(\"^[a-z]+$\", self)"`
+	F2 string `json:"p_2" check:"str.Match(\"^[a-z]+$\", self) && str.Match(\"^[0-9]*$\", sup.F1) == false"`
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache(CacheConfig{ExprSize: 2, TypeSize: 8})
+	v := New(WithCache(c))
+	assert.Equal(t, c, v.Cache())
+	assert.Equal(t, SharedCache(), New().Cache())
+
+	v.Validate(testA{})
+	v.Validate(testA{})
+	stats := c.Stats()
+	assert.Equal(t, CacheCounters{Size: 8, Len: 1, Hits: 1, Misses: 1}, stats.Types)
+	assert.Equal(t, CacheCounters{Size: 2, Len: 1, Hits: 1, Misses: 1}, stats.Exprs)
+
+	v.Validate(testC{}) // five distinct expressions through a cache of two
+	stats = c.Stats()
+	assert.Equal(t, 2, stats.Exprs.Len)
+	assert.Equal(t, uint64(4), stats.Exprs.Evictions)
+
+	c.Reset()
+	assert.Equal(t, CacheStats{
+		Exprs: CacheCounters{Size: 2},
+		Types: CacheCounters{Size: 8},
+	}, c.Stats())
+
+	c = NewCache(CacheConfig{ExprSize: 8, PatternSize: 8})
+	v = New(WithCache(c))
+	assert.Equal(t, []string{"p_1", "p_2"}, v.Validate(cacheP{F1: "1", F2: "b"}).Fields())
+	assert.Nil(t, v.Validate(cacheP{F1: "a", F2: "b"}))
+	assert.Equal(t, CacheCounters{Size: 8, Len: 2, Hits: 4, Misses: 2}, c.Stats().Patterns)
+
+	v = New(DisableCache())
+	assert.Equal(t, []string{"a_1"}, v.Validate(testA{}).Fields())
+	assert.Equal(t, CacheStats{}, v.Cache().Stats())
+	v.Cache().Reset()
+}
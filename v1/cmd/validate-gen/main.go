@@ -0,0 +1,65 @@
+// Command validate-gen generates methods which validate struct types
+// without reflection. It is intended to be run by go generate:
+//
+//	//go:generate go run github.com/bww/go-validate/v1/cmd/validate-gen
+//
+// By default code is generated for every struct type in the package which
+// has checked fields and whose expressions can be translated; types which
+// cannot be are reported and continue to be validated by reflection. Use
+// -type to generate code for specific types, in which case a type that
+// cannot be translated is an error.
+//
+// The generated code is only used by validators configured with the same
+// tags it was generated for; use -checktag, -errortag and -fieldtag if you
+// have changed them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bww/go-validate/v1/validategen"
+)
+
+func main() {
+	cmdline := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		fTypes    = cmdline.String("type", "", "comma-delimited names of the types to generate code for")
+		fOutput   = cmdline.String("output", "", "the name of the generated file; by default <package>_validate.go")
+		fCheckTag = cmdline.String("checktag", "check", "the name of the tag which contains validation expressions")
+		fErrorTag = cmdline.String("errortag", "invalid", "the name of the tag which contains error messages")
+		fFieldTag = cmdline.String("fieldtag", "json", "the name of the tag which contains field names")
+	)
+	cmdline.Parse(os.Args[1:])
+
+	dir := "."
+	if cmdline.NArg() > 0 {
+		dir = cmdline.Arg(0)
+	}
+
+	conf := validategen.Config{
+		Output:   *fOutput,
+		CheckTag: *fCheckTag,
+		ErrorTag: *fErrorTag,
+		FieldTag: *fFieldTag,
+	}
+	if *fTypes != "" {
+		conf.Types = strings.Split(*fTypes, ",")
+	}
+
+	file, err := validategen.Generate(dir, conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate-gen: %v\n", err)
+		os.Exit(1)
+	}
+	for _, e := range file.Skipped {
+		fmt.Fprintf(os.Stderr, "validate-gen: skipping %s: %s\n", e.Type, e.Reason)
+	}
+	err = os.WriteFile(file.Path, file.Source, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
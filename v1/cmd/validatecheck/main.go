@@ -0,0 +1,22 @@
+// Command validatecheck checks the validation tags of struct fields.
+//
+// It may be run directly on packages:
+//
+//	validatecheck ./...
+//
+// or by go vet:
+//
+//	go vet -vettool=$(which validatecheck) ./...
+//
+// Use -checktags to name the tags which contain expressions when you validate
+// using modes, for example -checktags=create,update.
+package main
+
+import (
+	"github.com/bww/go-validate/v1/validatecheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(validatecheck.Analyzer)
+}
@@ -0,0 +1,164 @@
+package validate
+
+import (
+	"reflect"
+	"sync"
+)
+
+// minChunk is the smallest number of slice elements which are validated
+// by a single goroutine; shorter slices are validated sequentially.
+const minChunk = 256
+
+// chunksPerWorker is the number of chunks a slice is divided into for each
+// goroutine, so that work remains balanced when elements vary in cost.
+const chunksPerWorker = 4
+
+// workers bounds the number of goroutines used to validate a single value.
+// The goroutine which is validating is always one of them, so the channel
+// holds a token for each additional goroutine.
+type workers chan struct{}
+
+func newWorkers(n int) workers {
+	if n <= 1 {
+		return nil
+	}
+	return make(workers, n-1)
+}
+
+// size is the total number of goroutines which may be used
+func (w workers) size() int {
+	return cap(w) + 1
+}
+
+// batch is a set of tasks which must all complete before the validator can
+// proceed.
+type batch struct {
+	w     workers
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+	fault interface{} // the first value a task panicked with, if any
+}
+
+// do runs a task on another goroutine if one is available, or on the
+// calling goroutine otherwise. Since tasks never wait for a goroutine to
+// become available, nested batches cannot deadlock. Panics are recovered
+// wherever the task runs, so they are only raised again by wait.
+func (b *batch) do(f func()) {
+	select {
+	case b.w <- struct{}{}:
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			defer func() { <-b.w }()
+			defer b.recover()
+			f()
+		}()
+	default:
+		func() {
+			defer b.recover()
+			f()
+		}()
+	}
+}
+
+func (b *batch) recover() {
+	if r := recover(); r != nil {
+		b.mu.Lock()
+		if b.fault == nil {
+			b.fault = r
+		}
+		b.mu.Unlock()
+	}
+}
+
+// wait waits for every task to complete. If any task panicked, which
+// indicates a configuration error, the panic is raised again on the
+// calling goroutine.
+func (b *batch) wait() {
+	b.wg.Wait()
+	if b.fault != nil {
+		panic(b.fault)
+	}
+}
+
+// fork creates a new, empty buffer which validates beneath the provided
+// path independently of the receiver, so that it can be used by another
+// goroutine.
+func (e *errorBuffer) fork(base string) *errorBuffer {
+	f := newErrorBuffer(base, e.T, e.O)
+	f.G = e.G
+	return f
+}
+
+// join adds the errors and warnings from each of the provided buffers, in
+// order, and releases them.
+func (e *errorBuffer) join(bufs []*errorBuffer) {
+	for _, b := range bufs {
+		e.Add(b.E...)
+		e.Warn(b.W...)
+		b.release()
+	}
+}
+
+// validateSliceConcurrently validates the elements of a slice in chunks,
+// each of which is validated into its own buffer so that errors can be
+// reported in index order.
+func (v Validator) validateSliceConcurrently(s reflect.Value, errs *errorBuffer) bool {
+	l := s.Len()
+	size := max(minChunk, (l+errs.G.size()*chunksPerWorker-1)/(errs.G.size()*chunksPerWorker))
+	n := (l + size - 1) / size
+
+	base := errs.path()
+	bufs := make([]*errorBuffer, n)
+	valid := make([]bool, n)
+	b := &batch{w: errs.G}
+	for i := 0; i < n; i++ {
+		bufs[i] = errs.fork(base)
+		b.do(func() {
+			valid[i] = v.validateElems(s, i*size, min(l, (i+1)*size), bufs[i])
+		})
+	}
+	b.wait()
+
+	errs.join(bufs)
+	return allValid(valid)
+}
+
+// validateStructConcurrently validates the fields of a struct which
+// recurse via check() concurrently with their siblings. Every field is
+// validated into its own buffer so that errors can be reported in field
+// order.
+func (v Validator) validateStructConcurrently(s reflect.Value, vt *validatedType, errs *errorBuffer) bool {
+	en := acquireEnv(v, s)
+	defer en.release()
+
+	base := errs.path()
+	bufs := make([]*errorBuffer, len(vt.Fields))
+	valid := make([]bool, len(vt.Fields))
+	b := &batch{w: errs.G}
+	for i, e := range vt.Fields {
+		bufs[i] = errs.fork(base)
+		if e.Subtree {
+			b.do(func() {
+				en := acquireEnv(v, s)
+				defer en.release()
+				valid[i] = v.validateStructField(s, e, en, bufs[i])
+			})
+		} else {
+			valid[i] = v.validateStructField(s, e, en, bufs[i])
+		}
+	}
+	b.wait()
+
+	errs.join(bufs)
+	return allValid(valid)
+}
+
+func allValid(v []bool) bool {
+	for _, e := range v {
+		if !e {
+			return false
+		}
+	}
+	return true
+}
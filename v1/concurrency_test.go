@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type concA struct {
+	Items []concB `json:"items" check:"check"`
+	Left  *concC  `json:"left" check:"self == nil || check(self)"`
+	Right *concC  `json:"right" check:"self == nil || check(self)" invalid:"-"`
+	Name  string  `json:"name" check:"len(self) > 0"`
+	Old   string  `json:"old" deprecated:"Use name"`
+}
+
+type concB struct {
+	ID   int    `json:"id" check:"self % 7 != 0"`
+	Note string `json:"note" check:"len(self) < 4" severity:"warn"`
+}
+
+type concC struct {
+	Items []concB `json:"items" check:"check"`
+}
+
+type concD struct {
+	Items []concE `check:"check"`
+}
+
+type concE struct {
+	ID int `check:"self +"`
+}
+
+type concG struct {
+	Left  *concC `check:"self == nil || check (self)"`
+	Right *concC `check:"self == nil || check(self)"`
+	Name  string `check:"len(self) > 0"`
+}
+
+// concF validates its items using Check, as generated code does, and
+// records the goroutines available to it
+type concF struct {
+	Items []concB
+	g     *workers
+}
+
+func (s concF) Validate(v Validator, c Context) (error, bool) { // v3
+	*s.g = c.g
+	var res Result
+	valid := v.Check(c.WithField("items"), s.Items, &res)
+	return Errors(res.Errors), valid
+}
+
+func TestConcurrency(t *testing.T) {
+	items := func(n int) []concB {
+		v := make([]concB, n)
+		for i := range v {
+			v[i] = concB{ID: i + 1}
+			if i%100 == 0 {
+				v[i].Note = "Too long"
+			}
+		}
+		return v
+	}
+	x := concA{
+		Items: items(5000),
+		Left:  &concC{Items: items(1000)},
+		Right: &concC{Items: items(700)},
+		Old:   "Set",
+	}
+
+	expect := New(BasePath("root")).Evaluate(x)
+	assert.Len(t, expect.Errors, 5000/7+1+1000/7+700/7+1)
+	for _, n := range []int{-1, 0, 1, 2, 8, 64} {
+		res := New(BasePath("root"), Concurrency(n)).Evaluate(x)
+		assert.Equal(t, expect, res, fmt.Sprintf("concurrency: %d", n))
+		if len(res.Errors) > 1 {
+			assert.Equal(t, "root.items[6].id", res.Errors[0].(*FieldError).Field)
+		}
+	}
+
+	// configuration errors are raised on the calling goroutine
+	y := concD{Items: make([]concE, 1000)}
+	assert.Panics(t, func() {
+		New(Concurrency(4)).Validate(y)
+	})
+}
+
+func TestConcurrencySubtrees(t *testing.T) {
+	errs := newErrorBuffer("", nil, nil)
+	defer errs.release()
+	vt, err := New().validatedType(reflect.TypeOf(concG{}), errs)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, vt.Subtrees) // as Prepare finds them
+	}
+}
+
+func TestConcurrencyCheck(t *testing.T) {
+	var g workers
+	x := concF{Items: make([]concB, 1000), g: &g}
+	for i := range x.Items {
+		x.Items[i].ID = i
+	}
+
+	errs := New(Concurrency(4)).Validate(x)
+	assert.Len(t, errs, 1000/7+1)
+	assert.Equal(t, 4, g.size()) // values checked beneath the context share its goroutines
+
+	c := Context{Path: "a", g: g}
+	assert.Equal(t, g, c.WithField("b").WithIndex(1).WithFieldAlternates("c", "d").WithPath("e").g)
+}
+
+func TestBatchPanics(t *testing.T) {
+	b := &batch{w: make(workers)} // no goroutines are available, so tasks run inline
+	var ran []int
+	b.do(func() { ran = append(ran, 1) })
+	assert.NotPanics(t, func() {
+		b.do(func() { panic("first") })
+		b.do(func() { panic("second") })
+	})
+	b.do(func() { ran = append(ran, 2) })
+	assert.PanicsWithValue(t, "first", b.wait)
+	assert.Equal(t, []int{1, 2}, ran)
+}
+
+func BenchmarkValidateConcurrently(b *testing.B) {
+	x := concC{Items: make([]concB, 10000)}
+	for i := range x.Items {
+		x.Items[i].ID = 1
+	}
+	for _, n := range []int{1, 8} {
+		v := New(Concurrency(n))
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				v.Validate(x)
+			}
+		})
+	}
+}
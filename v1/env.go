@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/bww/epl/v1"
+	"github.com/bww/go-validate/v1/stdlib"
+)
+
+// errUndefined is the error EPL expects a context to produce for a
+// variable it does not define, which causes the variable to be looked up
+// in the EPL standard library instead. Since EPL does not export it, we
+// obtain it by evaluating an identifier which nothing defines.
+var errUndefined = func() error {
+	prog, err := epl.Compile("undefined")
+	if err != nil {
+		panic(fmt.Errorf("validate: %v", err))
+	}
+	_, err = prog.Exec(map[string]interface{}{})
+	if err == nil {
+		panic(fmt.Errorf("validate: Expected an undefined variable"))
+	}
+	return err
+}()
+
+var envs = sync.Pool{
+	New: func() interface{} {
+		return &env{}
+	},
+}
+
+// env is the environment in which expressions are evaluated. An env is
+// obtained from a pool and used for every field of a struct, so that
+// evaluating an expression does not require a new environment; the struct
+// itself is only boxed if an expression refers to it.
+type env struct {
+	v     Validator
+	errs  *errorBuffer
+	self  interface{}
+	sup   reflect.Value
+	boxed interface{} // the boxed value of sup, once it has been requested
+	check func(interface{}) bool
+	str   stdlib.Strings // the str namespace, which reports the patterns it compiles to the observer
+}
+
+func acquireEnv(v Validator, s reflect.Value) *env {
+	e := envs.Get().(*env)
+	if e.check == nil {
+		e.check = e.recurse // bind once, since a method value allocates
+		e.str = stdlib.Strings{Compile: e.pattern}
+	}
+	e.v, e.sup = v, s
+	return e
+}
+
+func (e *env) release() {
+	*e = env{check: e.check, str: e.str}
+	envs.Put(e)
+}
+
+// Variable implements epl.Context
+func (e *env) Variable(name string) (interface{}, error) {
+	switch name {
+	case "self":
+		return e.self, nil
+	case "sup", "super":
+		if e.boxed == nil && e.sup.CanInterface() {
+			e.boxed = e.sup.Interface()
+		}
+		if e.boxed != nil {
+			return e.boxed, nil
+		}
+	case "len":
+		return length, nil
+	case "now":
+		return time.Now, nil
+	case "date":
+		return date, nil
+	case "check":
+		return e.check, nil
+	case "str":
+		return e.str, nil
+	default:
+		if ns, ok := stdlib.Namespace(name); ok {
+			return ns, nil
+		}
+	}
+	return nil, errUndefined
+}
+
+// RegisterPattern registers a regular expression by name, so that it can be
+// used by any expression as str.Pattern(name, self); see
+// [stdlib.RegisterPattern].
+func RegisterPattern(name, pattern string) error {
+	return stdlib.RegisterPattern(name, pattern)
+}
+
+// pattern compiles a pattern provided to str.Match by way of the
+// validator's cache
+func (e *env) pattern(src string) (*regexp.Regexp, error) {
+	return e.v.Cache().pattern(src, e.errs)
+}
+
+// recurse validates a value beneath the field which is being checked; it
+// implements check().
+func (e *env) recurse(x interface{}) bool {
+	return e.v.validate(reflect.ValueOf(x), e.errs)
+}
+
+func length(s interface{}) int {
+	z := reflect.ValueOf(s)
+	switch z.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return z.Len()
+	default:
+		panic(fmt.Errorf("validate: Type does not have a length: %T", s))
+	}
+}
+
+func date(y, m, d float64) time.Time {
+	return time.Date(int(y), time.Month(m), int(d), 0, 0, 0, 0, time.UTC)
+}
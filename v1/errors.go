@@ -3,6 +3,9 @@ package validate
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
 
 	"github.com/bww/go-util/v1/ext"
 )
@@ -29,6 +32,28 @@ func (e FieldError) Error() string {
 	return fmt.Sprintf("%v: %v", e.Field, e.Message)
 }
 
+// asFieldError returns the field error represented by err, if err is a
+// field error, either by value or by reference.
+func asFieldError(err error) (*FieldError, bool) {
+	switch c := err.(type) {
+	case FieldError:
+		return &c, true
+	case *FieldError:
+		return c, c != nil
+	default:
+		return nil, false
+	}
+}
+
+// LogValue is implemented so that field errors are logged as structured
+// attributes rather than as a formatted string.
+func (e FieldError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("field", e.Field),
+		slog.String("message", e.Message),
+	)
+}
+
 type Errors []error
 
 func (e Errors) Fields() []string {
@@ -71,14 +96,203 @@ func (e Errors) Error() string {
 	return s
 }
 
+// LogValue is implemented so that errors are logged as structured
+// attributes: the number of errors, followed by each error keyed by its
+// index.
+func (e Errors) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(e)+1)
+	attrs = append(attrs, slog.Int("count", len(e)))
+	for i, v := range e {
+		if c, ok := asFieldError(v); ok {
+			attrs = append(attrs, slog.Any(strconv.Itoa(i), c))
+		} else {
+			attrs = append(attrs, slog.String(strconv.Itoa(i), v.Error()))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
 // MarshalJSON is implemented to indicate that the error can be marshaled
-// to a reasonable JSON value.
+// to a reasonable JSON value. Errors which are not field errors are
+// represented as field errors for the placeholder field "<entity>".
 func (e Errors) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Error string  `json:"error"`
-		Cause []error `json:"fields"`
-	}{
-		Error: fmt.Sprintf("%d field %s", len(e), ext.Choose(len(e) == 1, "error", "errors")),
-		Cause: []error(e),
+	fields := make([]error, len(e))
+	for i, v := range e {
+		if _, ok := asFieldError(v); ok {
+			fields[i] = v
+		} else {
+			fields[i] = newFieldError(entityPath, v)
+		}
+	}
+	return json.Marshal(errorsDocument{
+		Error:  fmt.Sprintf("%d field %s", len(e), ext.Choose(len(e) == 1, "error", "errors")),
+		Fields: fields,
+	})
+}
+
+// UnmarshalJSON decodes the document produced by [Errors.MarshalJSON] into
+// the receiver. Every decoded error is a *FieldError. The causes of field
+// errors are not serialized and are therefore nil after decoding.
+func (e *Errors) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Fields []*FieldError `json:"fields"`
+	}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return err
+	}
+	errs := make(Errors, 0, len(doc.Fields))
+	for _, v := range doc.Fields {
+		if v != nil {
+			errs = append(errs, v)
+		}
+	}
+	*e = errs
+	return nil
+}
+
+type errorsDocument struct {
+	Error  string  `json:"error"`
+	Fields []error `json:"fields"`
+}
+
+// WithPrefix returns a copy of the receiver with every error re-rooted
+// beneath the provided path. This is useful when errors produced by
+// validating a sub-value are reported as part of a containing value.
+// Errors which are not field errors are wrapped in a field error at the
+// prefix path.
+func (e Errors) WithPrefix(p string) Errors {
+	res := make(Errors, 0, len(e))
+	for _, v := range e {
+		if c, ok := asFieldError(v); ok {
+			res = append(res, &FieldError{Field: joinPath(p, c.Field), Message: c.Message, Cause: c.Cause})
+		} else {
+			res = append(res, newFieldError(coalesce(p, entityPath), v))
+		}
+	}
+	return res
+}
+
+// Merge returns a new set of errors containing the errors in the receiver
+// followed by the provided errors. Nil errors, including nil field errors
+// and sets of errors, are ignored and sets of errors are flattened.
+func (e Errors) Merge(errs ...error) Errors {
+	res := make(Errors, 0, len(e)+len(errs))
+	res = append(res, e...)
+	for _, v := range errs {
+		switch c := v.(type) {
+		case nil:
+		case Errors:
+			res = append(res, c...)
+		case *FieldError:
+			if c != nil {
+				res = append(res, c)
+			}
+		default:
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Filter returns a new set of errors containing only the errors in the
+// receiver for which the provided function returns true.
+func (e Errors) Filter(f func(error) bool) Errors {
+	res := make(Errors, 0, len(e))
+	for _, v := range e {
+		if f(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// ByField groups field errors by their field path. Errors which are not
+// field errors are grouped under the placeholder path "<entity>".
+func (e Errors) ByField() map[string][]*FieldError {
+	res := make(map[string][]*FieldError)
+	for _, v := range e {
+		c, ok := asFieldError(v)
+		if !ok {
+			c = newFieldError(entityPath, v)
+		}
+		res[c.Field] = append(res[c.Field], c)
+	}
+	return res
+}
+
+// At returns the errors reported for the field at the provided path or
+// for any field beneath it. Index subscripts in the path may be specified
+// as the wildcard [*], which matches any index: "items[*].sku" matches
+// errors for the sku field of every element of items.
+func (e Errors) At(p string) Errors {
+	q := parsePath(p)
+	return e.Filter(func(v error) bool {
+		if c, ok := asFieldError(v); ok {
+			return matchPath(q, parsePath(c.Field))
+		} else {
+			return len(q) == 0
+		}
+	})
+}
+
+// Sort returns a copy of the receiver sorted by field path and then by
+// message. Paths are ordered component-wise and indexes are compared
+// numerically. Errors which are not field errors are sorted first and
+// otherwise retain their relative order.
+func (e Errors) Sort() Errors {
+	type entry struct {
+		path []pathElem
+		msg  string
+		err  error
+		leaf bool
+	}
+	ents := make([]entry, len(e))
+	for i, v := range e {
+		if c, ok := asFieldError(v); ok {
+			ents[i] = entry{parsePath(c.Field), c.Message, v, true}
+		} else {
+			ents[i] = entry{err: v}
+		}
+	}
+	sort.SliceStable(ents, func(i, j int) bool {
+		a, b := ents[i], ents[j]
+		if a.leaf != b.leaf {
+			return !a.leaf
+		}
+		if c := comparePaths(a.path, b.path); c != 0 {
+			return c < 0
+		}
+		return a.msg < b.msg
+	})
+	res := make(Errors, len(ents))
+	for i, v := range ents {
+		res[i] = v.err
+	}
+	return res
+}
+
+// Dedup returns a copy of the receiver with duplicate errors removed,
+// retaining the first occurrence of each. Field errors are duplicates
+// when they have the same field and message; other errors when their
+// error strings are equal.
+func (e Errors) Dedup() Errors {
+	type key struct {
+		field, msg string
+		leaf       bool
+	}
+	seen := make(map[key]struct{}, len(e))
+	return e.Filter(func(v error) bool {
+		var k key
+		if c, ok := asFieldError(v); ok {
+			k = key{c.Field, c.Message, true}
+		} else {
+			k = key{msg: v.Error()}
+		}
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+		return true
 	})
 }
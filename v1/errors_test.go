@@ -1,7 +1,10 @@
 package validate
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,3 +15,90 @@ func TestFieldErrors(t *testing.T) {
 	ferr := newFieldError("field", someErr)
 	assert.Equal(t, true, errors.Is(ferr, someErr))
 }
+
+func TestErrorsAlgebra(t *testing.T) {
+	someErr := errors.New("Some error")
+	errs := Errors{
+		FieldErrorf("items[10].sku", "Duplicate"),
+		FieldErrorf("items[2].sku", "Empty"),
+		FieldErrorf("items[2].qty", "Too small"),
+		FieldErrorf("{f1,first}", "Too big"),
+		FieldErrorf("items[2].sku", "Empty"),
+		someErr,
+	}
+
+	assert.Equal(t, []string{"order.items[10].sku", "order.items[2].sku", "order.items[2].qty", "order.{f1,first}", "order.items[2].sku", "order"}, errs.WithPrefix("order").Fields())
+	assert.Equal(t, []string{"[0].a", "[0]"}, Errors{FieldErrorf("a", "A"), FieldErrorf(entityPath, "B")}.WithPrefix("[0]").Fields())
+	assert.Equal(t, true, errors.Is(errs.WithPrefix("x")[5], someErr))
+
+	merged := Errors{FieldErrorf("a", "A")}.Merge(nil, Errors{FieldErrorf("b", "B")}, someErr)
+	assert.Equal(t, []string{"a", "b"}, merged.Fields())
+	assert.Len(t, merged, 3)
+
+	var nilField *FieldError
+	var nilErrs Errors
+	assert.Equal(t, Errors{someErr}, Errors{}.Merge(nilField, nilErrs, someErr))
+
+	assert.Equal(t, []string{"items[2].qty"}, errs.Filter(func(e error) bool {
+		f, ok := asFieldError(e)
+		return ok && f.Message == "Too small"
+	}).Fields())
+
+	byField := errs.ByField()
+	assert.Len(t, byField["items[2].sku"], 2)
+	assert.Len(t, byField[entityPath], 1)
+
+	assert.Equal(t, []string{"items[10].sku", "items[2].sku", "items[2].sku"}, errs.At("items[*].sku").Fields())
+	assert.Equal(t, []string{"items[2].sku", "items[2].qty", "items[2].sku"}, errs.At("items[2]").Fields())
+	assert.Equal(t, []string{"{f1,first}"}, errs.At("first").Fields())
+	assert.Len(t, errs.At(""), len(errs))
+
+	sorted := errs.Sort()
+	assert.Equal(t, someErr, sorted[0])
+	assert.Equal(t, []string{"items[2].qty", "items[2].sku", "items[2].sku", "items[10].sku", "{f1,first}"}, sorted.Fields())
+
+	assert.Equal(t, []string{"items[10].sku", "items[2].sku", "items[2].qty", "{f1,first}"}, errs.Dedup().Fields())
+	assert.Len(t, errs.Dedup(), 5)
+}
+
+func TestErrorsJSON(t *testing.T) {
+	errs := Errors{
+		FieldErrorf("a.b", "Invalid"),
+		FieldError{Field: "c[0]", Message: "Empty"},
+		errors.New("Some error"),
+	}
+	data, err := json.Marshal(errs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.JSONEq(t, `{"error":"3 field errors","fields":[{"field":"a.b","message":"Invalid"},{"field":"c[0]","message":"Empty"},{"field":"<entity>","message":"Some error"}]}`, string(data))
+
+	var dec Errors
+	err = json.Unmarshal(data, &dec)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a.b", "c[0]", "<entity>"}, dec.Fields())
+		assert.Equal(t, []string{"Invalid", "Empty", "Some error"}, dec.Messages())
+		var ferr *FieldError
+		assert.True(t, errors.As(error(dec), &ferr))
+		assert.Equal(t, "a.b", ferr.Field)
+	}
+
+	err = json.Unmarshal([]byte(`{"error":"0 field errors","fields":null}`), &dec)
+	if assert.NoError(t, err) {
+		assert.Len(t, dec, 0)
+	}
+}
+
+func TestErrorsLogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	log.Info("Invalid", "errors", Errors{FieldErrorf("a.b", "Invalid"), errors.New("Some error")})
+	assert.JSONEq(t, `{"level":"INFO","msg":"Invalid","errors":{"count":2,"0":{"field":"a.b","message":"Invalid"},"1":"Some error"}}`, buf.String())
+}
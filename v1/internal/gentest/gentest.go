@@ -0,0 +1,60 @@
+// Package gentest contains types for which validation code is generated,
+// to verify that generated code behaves the same way as reflection.
+package gentest
+
+import (
+	"time"
+)
+
+//go:generate go run github.com/bww/go-validate/v1/cmd/validate-gen
+
+type Account struct {
+	Name     string            `json:"name" check:"len(self) > 0 && str.AlphaNumeric(self)" invalid:"Name must be alphanumeric"`
+	Email    string            `json:"email,omitempty" check:"len(self) == 0 || str.Match(\"^[^@]+@[^@]+$\", self)"`
+	Age      int               `json:"age" check:"self >= 18 && self < 150"`
+	Score    float32           `check:"self * 2 <= 200 && self - 1 >= -1"`
+	Limit    uint8             `json:"limit" check:"self % 5 == 0"`
+	Enabled  bool              `json:"enabled" check:"self || sup.Age > 20"`
+	Code     Code              `json:"code" check:"sup.Age != 0 || self == sup.Code"`
+	Tags     []string          `json:"tags" check:"len(self) <= 3" invalid:"Too many tags"`
+	Roles    []string          `json:"roles" check:"coll.MinLen(self, 1) && coll.MaxLen(self, 3)"`
+	Labels   map[string]string `json:"labels" check:"self == nil || len(self) > 0"`
+	Created  time.Time         `json:"created" check:"self.After(date(2018, 1, 1)) && self.Before(now())"`
+	Owner    *Owner            `json:"owner" check:"self != nil && check(self)" invalid:"-"`
+	Owners   []*Owner          `json:"owners" check:"check"`
+	Renamed  string            `json:"renamed" check:"self == sup.Name + \"!\" || self == \"\""`
+	Nickname string            `json:"-" check:"sup.Display() != \"\""`
+	Ignored  string            `check:"-"`
+	Other    string
+}
+
+func (a Account) Display() string {
+	return a.Name + a.Nickname
+}
+
+type Code string
+
+type Owner struct {
+	ID    int    `json:"id" check:"self > 0"`
+	Kind  Kind   `json:"kind" check:"len(self) > 0"`
+	Email string `json:"email" check:"len(self) == 0 || net.Email(self)"`
+	Boss  *Owner `json:"boss" check:"self == nil || (self.ID != sup.ID && check(self))"`
+}
+
+type Kind string
+
+// Legacy cannot be generated, since it has an embedded field
+type Legacy struct {
+	Owner
+	Note string `json:"note" check:"len(self) > 0"`
+}
+
+// Dynamic cannot be generated, since the type of its field is not known
+type Dynamic struct {
+	Value interface{} `json:"value" check:"self != nil"`
+}
+
+// Soft cannot be generated, since its field has a severity of warning
+type Soft struct {
+	Nickname string `json:"nickname" check:"str.Len(self) < 16" severity:"warn"`
+}
@@ -0,0 +1,105 @@
+package gentest
+
+import (
+	"testing"
+	"time"
+
+	validate "github.com/bww/go-validate/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAccount() Account {
+	return Account{
+		Name:     "gopher",
+		Email:    "gopher@example.com",
+		Age:      30,
+		Score:    50,
+		Limit:    10,
+		Code:     "abc",
+		Roles:    []string{"admin"},
+		Created:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Owner:    &Owner{ID: 1, Kind: "user"},
+		Renamed:  "gopher!",
+		Nickname: "go",
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	tests := []struct {
+		Value  interface{}
+		Fields []string
+	}{
+		{
+			validAccount(),
+			[]string{},
+		},
+		{
+			func() Account {
+				a := validAccount()
+				a.Name = "go pher"
+				a.Email = "nope"
+				a.Age = 17
+				a.Score = 101
+				a.Limit = 7
+				a.Tags = []string{"a", "b", "c", "d"}
+				a.Roles = nil
+				a.Labels = map[string]string{}
+				a.Renamed = "other"
+				return a
+			}(),
+			[]string{"name", "email", "age", "Score", "limit", "enabled", "tags", "roles", "labels", "renamed"},
+		},
+		{
+			func() Account {
+				a := validAccount()
+				a.Age = 0
+				a.Created = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+				a.Owner = nil
+				a.Owners = []*Owner{{ID: 1, Kind: "user"}, {ID: 0}, {ID: 2, Kind: "user", Boss: &Owner{ID: 2, Kind: "admin"}}}
+				a.Name, a.Nickname, a.Renamed = "", "", ""
+				return a
+			}(),
+			[]string{"name", "age", "enabled", "created", "owners[1].id", "owners[1].kind", "owners[2].boss", "-"},
+		},
+		{
+			&Owner{ID: 1, Kind: "admin", Email: "admin", Boss: &Owner{ID: 3, Email: "boss@example.com", Boss: &Owner{ID: -1, Kind: "user"}}},
+			[]string{"email", "boss.kind", "boss.boss.id", "boss.boss", "boss"},
+		},
+		{
+			[]Owner{{ID: 1, Kind: "user"}, {}},
+			[]string{"[1].id", "[1].kind"},
+		},
+	}
+	generated, reflective := validate.New(), validate.New(validate.Reflective())
+	for _, e := range tests {
+		expect := reflective.Evaluate(e.Value)
+		assert.Equal(t, e.Fields, expect.Errors.Fields(), "%#v", e.Value)
+		assert.Equal(t, expect, generated.Evaluate(e.Value), "%#v", e.Value)
+	}
+}
+
+func TestGeneratedTags(t *testing.T) {
+	var res validate.Result
+	_, ok := validAccount().ValidateGenerated(validate.New(), validate.Context{}, &res)
+	assert.True(t, ok)
+	_, ok = validAccount().ValidateGenerated(validate.New(validate.FieldTag("name")), validate.Context{}, &res)
+	assert.False(t, ok)
+
+	// a validator which considers different tags validates by reflection
+	v := validate.New(validate.FieldTag("name"))
+	assert.Equal(t, v.WithOptions(validate.Reflective()).Evaluate(validAccount()), v.Evaluate(validAccount()))
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	v, a := validate.New(), validAccount()
+	for i := 0; i < b.N; i++ {
+		v.Validate(a)
+	}
+}
+
+func BenchmarkReflective(b *testing.B) {
+	v, a := validate.New(validate.Reflective()), validAccount()
+	for i := 0; i < b.N; i++ {
+		v.Validate(a)
+	}
+}
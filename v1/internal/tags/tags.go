@@ -0,0 +1,131 @@
+// Package tags parses struct tags, supporting the nonstandard notation in
+// which several comma-delimited keys share a single value, as in:
+//
+//	create,update:"len(self) > 0"
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Entry is a single key-value pair of a struct tag. An entry has more than
+// one name when several comma-delimited keys share the value.
+type Entry struct {
+	Names []string
+	Value string
+}
+
+// Has determines if the entry has the provided name.
+func (e Entry) Has(name string) bool {
+	for _, n := range e.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SyntaxError describes a struct tag which could not be parsed.
+type SyntaxError struct {
+	Offset int      // the offset in the tag at which the error occurred
+	Names  []string // the names of the entry being parsed, if they are known
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	if len(e.Names) > 0 {
+		return fmt.Sprintf("%s at offset %d (%s)", e.Reason, e.Offset, strings.Join(e.Names, ","))
+	} else {
+		return fmt.Sprintf("%s at offset %d", e.Reason, e.Offset)
+	}
+}
+
+// Parse parses a struct tag into its entries. Parsing stops at the first
+// syntax error, which is returned along with the entries that were parsed
+// before it, with the exception of a value which cannot be unquoted: that
+// entry is omitted, the error is noted and parsing continues.
+func Parse(tag string) ([]Entry, error) {
+	var entries []Entry
+	var first error
+	off := 0
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag, off = tag[i:], off+i
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		// Strictly speaking, control chars include the range [0x7f, 0x9f], not just
+		// [0x00, 0x1f], but in practice, we ignore the multi-byte control characters
+		// as it is simpler to inspect the tag's bytes than the tag's runes.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return entries, coalesceErr(first, &SyntaxError{Offset: off, Names: splitNames(tag[:i]), Reason: "Expected a key followed by a quoted value"})
+		}
+		names := splitNames(tag[:i])
+		tag, off = tag[i+1:], off+i+1
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return entries, coalesceErr(first, &SyntaxError{Offset: off, Names: names, Reason: "Unterminated value"})
+		}
+
+		qvalue := tag[:i+1]
+		tag, off = tag[i+1:], off+i+1
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			first = coalesceErr(first, &SyntaxError{Offset: off - len(qvalue), Names: names, Reason: "Invalid quoted value"})
+			continue
+		}
+		entries = append(entries, Entry{Names: names, Value: value})
+	}
+	return entries, first
+}
+
+// Find looks up the value associated with the provided key in a struct
+// tag, considering every name of entries whose keys are combined.
+func Find(tag reflect.StructTag, key string) (string, bool) {
+	entries, _ := Parse(string(tag))
+	for _, e := range entries {
+		if e.Has(key) {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+func splitNames(s string) []string {
+	if s == "" {
+		return nil
+	}
+	names := strings.Split(s, ",")
+	for i, e := range names {
+		names[i] = strings.TrimSpace(e)
+	}
+	return names
+}
+
+func coalesceErr(a, b error) error {
+	if a != nil {
+		return a
+	} else {
+		return b
+	}
+}
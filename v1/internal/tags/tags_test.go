@@ -0,0 +1,63 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Tag     string
+		Entries []Entry
+		Error   string
+	}{
+		{
+			Tag:     ``,
+			Entries: nil,
+		},
+		{
+			Tag: `json:"a" check:"self > 0"`,
+			Entries: []Entry{
+				{Names: []string{"json"}, Value: "a"},
+				{Names: []string{"check"}, Value: "self > 0"},
+			},
+		},
+		{
+			Tag: `create,update:"len(self) > 0"`,
+			Entries: []Entry{
+				{Names: []string{"create", "update"}, Value: "len(self) > 0"},
+			},
+		},
+		{
+			Tag: `json:"a" check:self`,
+			Entries: []Entry{
+				{Names: []string{"json"}, Value: "a"},
+			},
+			Error: "Expected a key followed by a quoted value at offset 9 (check)",
+		},
+		{
+			Tag: `json:"a" invalid:"Oops`,
+			Entries: []Entry{
+				{Names: []string{"json"}, Value: "a"},
+			},
+			Error: "Unterminated value at offset 17 (invalid)",
+		},
+		{
+			Tag: `check:"\q" json:"a"`,
+			Entries: []Entry{
+				{Names: []string{"json"}, Value: "a"},
+			},
+			Error: "Invalid quoted value at offset 6 (check)",
+		},
+	}
+	for _, e := range tests {
+		entries, err := Parse(e.Tag)
+		assert.Equal(t, e.Entries, entries, e.Tag)
+		if e.Error != "" {
+			assert.EqualError(t, err, e.Error, e.Tag)
+		} else {
+			assert.NoError(t, err, e.Tag)
+		}
+	}
+}
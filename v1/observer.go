@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"reflect"
+	"time"
+)
+
+// CacheKind identifies one of the caches used by a validator.
+type CacheKind int
+
+const (
+	ExprCache    CacheKind = iota // compiled expressions
+	TypeCache                     // the validated fields of struct types
+	PatternCache                  // compiled regular expressions
+)
+
+func (c CacheKind) String() string {
+	switch c {
+	case ExprCache:
+		return "expr"
+	case TypeCache:
+		return "type"
+	case PatternCache:
+		return "pattern"
+	default:
+		return "unknown"
+	}
+}
+
+// Observer is notified of events that occur during validation, for
+// example to collect metrics or to trace validation. Observers may be
+// invoked concurrently and must be safe for concurrent use.
+//
+// Embed [NopObserver] to implement only the callbacks you care about.
+type Observer interface {
+	// OnValidateStart is invoked when validation of a value begins. The
+	// type is nil if the validated value is nil.
+	OnValidateStart(t reflect.Type)
+	// OnValidateEnd is invoked when validation of a value ends, with the
+	// result and the time it took.
+	OnValidateEnd(t reflect.Type, res Result, d time.Duration)
+	// OnCheck is invoked when a field check has been evaluated, with the
+	// path of the field, the source of the expression, whether the check
+	// was satisfied, and the time evaluation took, including any
+	// sub-validations.
+	OnCheck(path, expr string, ok bool, d time.Duration)
+	// OnIntrospector is invoked when the Validate method of a type which
+	// implements one of the introspector interfaces has been invoked.
+	OnIntrospector(t reflect.Type, d time.Duration)
+	// OnCacheHit is invoked when an entry is found in a cache.
+	OnCacheHit(c CacheKind)
+	// OnCacheMiss is invoked when an entry is not found in a cache.
+	OnCacheMiss(c CacheKind)
+}
+
+// NopObserver implements every method of [Observer] by doing nothing.
+type NopObserver struct{}
+
+func (NopObserver) OnValidateStart(reflect.Type)                      {}
+func (NopObserver) OnValidateEnd(reflect.Type, Result, time.Duration) {}
+func (NopObserver) OnCheck(string, string, bool, time.Duration)       {}
+func (NopObserver) OnIntrospector(reflect.Type, time.Duration)        {}
+func (NopObserver) OnCacheHit(CacheKind)                              {}
+func (NopObserver) OnCacheMiss(CacheKind)                             {}
+
+// span tracks a check which is being traced or observed
+type span struct {
+	step  *Step
+	path  string
+	expr  string
+	typ   reflect.Type
+	intro bool
+	start time.Time
+}
+
+// begin starts tracking a check if we are tracing or observing; otherwise
+// it does nothing.
+func (e *errorBuffer) begin(expr string, val reflect.Value, intro bool) span {
+	if e.T == nil && e.O == nil {
+		return span{}
+	}
+	p := e.path()
+	s := span{
+		path:  p,
+		expr:  expr,
+		intro: intro,
+		start: time.Now(),
+	}
+	if intro {
+		s.typ = val.Type()
+	}
+	if e.T != nil {
+		s.step = e.T.begin(p, expr, val, intro)
+	}
+	return s
+}
+
+// end finishes tracking a check started by begin.
+func (e *errorBuffer) end(s span, valid bool) {
+	if s.start.IsZero() {
+		return
+	}
+	d := time.Since(s.start)
+	if s.step != nil {
+		e.T.end(s.step, valid, d)
+	}
+	if e.O != nil {
+		switch {
+		case s.intro:
+			e.O.OnIntrospector(s.typ, d)
+		case s.expr != "":
+			e.O.OnCheck(s.path, s.expr, valid, d)
+		}
+	}
+}
+
+// cached notifies the observer, if any, of a cache lookup.
+func (e *errorBuffer) cached(c CacheKind, hit bool) {
+	if e == nil || e.O == nil {
+		return
+	}
+	if hit {
+		e.O.OnCacheHit(c)
+	} else {
+		e.O.OnCacheMiss(c)
+	}
+}
@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	NopObserver
+	sync.Mutex
+	starts, ends []reflect.Type
+	checks       map[string]bool
+	intros       []reflect.Type
+	results      []Result
+	caches       map[string]int
+}
+
+func (o *recordingObserver) OnValidateStart(t reflect.Type) {
+	o.Lock()
+	defer o.Unlock()
+	o.starts = append(o.starts, t)
+}
+
+func (o *recordingObserver) OnValidateEnd(t reflect.Type, res Result, d time.Duration) {
+	o.Lock()
+	defer o.Unlock()
+	o.ends = append(o.ends, t)
+	o.results = append(o.results, res)
+}
+
+func (o *recordingObserver) OnCheck(path, expr string, ok bool, d time.Duration) {
+	o.Lock()
+	defer o.Unlock()
+	if o.checks == nil {
+		o.checks = make(map[string]bool)
+	}
+	o.checks[path+": "+expr] = ok
+}
+
+func (o *recordingObserver) OnIntrospector(t reflect.Type, d time.Duration) {
+	o.Lock()
+	defer o.Unlock()
+	o.intros = append(o.intros, t)
+}
+
+func (o *recordingObserver) OnCacheHit(c CacheKind) {
+	o.cached(c.String() + " hit")
+}
+
+func (o *recordingObserver) OnCacheMiss(c CacheKind) {
+	o.cached(c.String() + " miss")
+}
+
+func (o *recordingObserver) cached(k string) {
+	o.Lock()
+	defer o.Unlock()
+	if o.caches == nil {
+		o.caches = make(map[string]int)
+	}
+	o.caches[k]++
+}
+
+func TestObserver(t *testing.T) {
+	o := &recordingObserver{}
+	v := New(Observe(o))
+
+	errs := v.Validate(testB{&testA{}})
+	assert.Equal(t, []string{"b_1.a_1", "b_1"}, errs.Fields())
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(testB{})}, o.starts)
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(testB{})}, o.ends)
+	assert.Equal(t, errs, o.results[0].Errors)
+	assert.Equal(t, map[string]bool{
+		"b_1: self != nil && check(self)": false,
+		"b_1.a_1: len(self) > 0":          false,
+	}, o.checks)
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(testA{})}, o.intros)
+
+	v.Explain(testC{})
+	assert.Len(t, o.ends, 2)
+	assert.Len(t, o.checks, 7)
+}
+
+func TestObserverCache(t *testing.T) {
+	o := &recordingObserver{}
+	v := New(Observe(o), PrivateCache(CacheConfig{ExprSize: 8, TypeSize: 8, PatternSize: 8}))
+
+	assert.Nil(t, v.Validate(cacheP{F1: "a", F2: "b"}))
+	assert.Nil(t, v.Validate(cacheP{F1: "a", F2: "b"}))
+	assert.Equal(t, map[string]int{
+		"type miss":    1,
+		"type hit":     1,
+		"expr miss":    2,
+		"expr hit":     2,
+		"pattern miss": 2,
+		"pattern hit":  4,
+	}, o.caches)
+}
@@ -1,10 +1,25 @@
 package validate
 
+import (
+	"log/slog"
+	"reflect"
+)
+
 type Config struct {
-	CheckTag string
-	ErrorTag string
-	FieldTag string
-	BasePath string
+	CheckTag      string
+	ErrorTag      string
+	FieldTag      string
+	SeverityTag   string
+	DeprecatedTag string
+	BasePath      string
+	OnDeprecated  DeprecationHandler
+	Logger        *slog.Logger
+	Observer      Observer
+	Unsupported   UnsupportedPolicy
+	KindHandlers  map[reflect.Kind]KindHandler
+	Cache         *Cache // nil uses the shared cache
+	Reflective    bool   // ignore generated implementations
+	Concurrency   int    // the number of goroutines used to validate a value; at most 1 is sequential
 }
 
 func (c Config) WithOptions(opts []Option) Config {
@@ -41,6 +56,121 @@ func FieldTag(name string) Option {
 	}
 }
 
+// SeverityTag sets the name of the tag which describes how a failed check
+// is reported, by default "severity". An empty name disables severities,
+// for structs which use the tag for another purpose.
+func SeverityTag(name string) Option {
+	return func(c Config) Config {
+		c.SeverityTag = name
+		return c
+	}
+}
+
+func DeprecatedTag(name string) Option {
+	return func(c Config) Config {
+		c.DeprecatedTag = name
+		return c
+	}
+}
+
+// OnDeprecated sets a handler which is invoked every time a deprecated
+// field is found to be set during validation, for example to count the
+// clients which still use it.
+func OnDeprecated(h DeprecationHandler) Option {
+	return func(c Config) Config {
+		c.OnDeprecated = h
+		return c
+	}
+}
+
+// Logger sets the logger used for diagnostics, such as unsupported types
+// and cache misses. When no logger is set the default logger is used.
+func Logger(l *slog.Logger) Option {
+	return func(c Config) Config {
+		c.Logger = l
+		return c
+	}
+}
+
+// Observe sets an observer which is notified of events that occur during
+// validation.
+func Observe(o Observer) Option {
+	return func(c Config) Config {
+		c.Observer = o
+		return c
+	}
+}
+
+// UnsupportedTypes sets the policy that determines how values of types
+// which cannot be validated are handled.
+func UnsupportedTypes(p UnsupportedPolicy) Option {
+	return func(c Config) Config {
+		c.Unsupported = p
+		return c
+	}
+}
+
+// HandleKind sets a handler which validates values of the specified kind
+// when that kind is not otherwise supported, such as channels, functions
+// or unsafe pointers. Handlers take precedence over the unsupported type
+// policy.
+func HandleKind(k reflect.Kind, h KindHandler) Option {
+	return func(c Config) Config {
+		m := make(map[reflect.Kind]KindHandler, len(c.KindHandlers)+1)
+		for k, v := range c.KindHandlers {
+			m[k] = v
+		}
+		m[k] = h
+		c.KindHandlers = m
+		return c
+	}
+}
+
+// WithCache sets the cache used by a validator. By default validators
+// share a cache; provide a cache to isolate a validator from others, or to
+// share a cache among a particular set of validators.
+func WithCache(c *Cache) Option {
+	return func(conf Config) Config {
+		conf.Cache = c
+		return conf
+	}
+}
+
+// PrivateCache creates a new cache with the provided configuration for
+// the exclusive use of the validator.
+func PrivateCache(cc CacheConfig) Option {
+	return WithCache(NewCache(cc))
+}
+
+// DisableCache disables caching for the validator, which is equivalent to
+// using a private cache with no capacity.
+func DisableCache() Option {
+	return PrivateCache(CacheConfig{})
+}
+
+// Reflective disables generated validation code, so that every value is
+// validated by reflection. This is mostly useful to compare the results of
+// both.
+func Reflective() Option {
+	return func(c Config) Config {
+		c.Reflective = true
+		return c
+	}
+}
+
+// Concurrency validates large slices, and the fields of a struct which
+// recurse via check(), using up to n goroutines for each value that is
+// validated. Errors are reported in the same order as they would be
+// sequentially. Observers and deprecation handlers must be safe for
+// concurrent use. Validation is sequential when n is less than 2, and
+// always while it is being explained.
+func Concurrency(n int) Option {
+	return func(c Config) Config {
+		c.Concurrency = n
+		return c
+	}
+}
+
 func BasePath(path string) Option {
 	return func(c Config) Config {
 		c.BasePath = path
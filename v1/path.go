@@ -0,0 +1,217 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	noIndex  = -1 // the element is a key
+	anyIndex = -2 // the element is a [*] wildcard subscript
+)
+
+// pathElem is a single component of a field path: either a key, which may
+// be an alternates group like "{a,b}", or an index subscript.
+type pathElem struct {
+	Key   string
+	Index int
+}
+
+func (e pathElem) IsIndex() bool {
+	return e.Index != noIndex
+}
+
+// Alternates returns the keys this element refers to. An alternates group
+// like "{a,b}" refers to each of its members, any other key refers only
+// to itself.
+func (e pathElem) Alternates() []string {
+	if l := len(e.Key); l > 1 && e.Key[0] == '{' && e.Key[l-1] == '}' {
+		return strings.Split(e.Key[1:l-1], ",")
+	} else {
+		return []string{e.Key}
+	}
+}
+
+// parsePath splits a field path, as produced by the validator, into its
+// components. The placeholder path used for errors that are not associated
+// with a field is equivalent to the empty path.
+func parsePath(p string) []pathElem {
+	if p == entityPath {
+		return nil
+	}
+	var elems []pathElem
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '.':
+			i++
+		case '[':
+			x := strings.IndexByte(p[i:], ']')
+			if x < 0 {
+				elems = append(elems, pathElem{Key: p[i:], Index: noIndex})
+				return elems
+			}
+			elems = append(elems, parseSubscript(p[i+1:i+x]))
+			i += x + 1
+		case '{':
+			x := strings.IndexByte(p[i:], '}')
+			if x < 0 {
+				elems = append(elems, pathElem{Key: p[i:], Index: noIndex})
+				return elems
+			}
+			elems = append(elems, pathElem{Key: p[i : i+x+1], Index: noIndex})
+			i += x + 1
+		default:
+			x := strings.IndexAny(p[i:], ".[")
+			if x < 0 {
+				x = len(p) - i
+			}
+			elems = append(elems, pathElem{Key: p[i : i+x], Index: noIndex})
+			i += x
+		}
+	}
+	return elems
+}
+
+func parseSubscript(s string) pathElem {
+	if s == "*" {
+		return pathElem{Index: anyIndex}
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return pathElem{Index: n}
+	}
+	return pathElem{Key: s, Index: noIndex}
+}
+
+// pathStack tracks the path to the value which is being validated. Elements
+// are pushed and popped as the validator descends into a value, which costs
+// nothing, and the path is only formatted when it is needed, typically
+// because an error is being reported.
+type pathStack struct {
+	base  string // the path beneath which everything is validated
+	elems []pathElem
+}
+
+func (s *pathStack) pushKey(k string) {
+	s.elems = append(s.elems, pathElem{Key: k, Index: noIndex})
+}
+
+func (s *pathStack) pushIndex(n int) {
+	s.elems = append(s.elems, pathElem{Index: n})
+}
+
+func (s *pathStack) pop() {
+	s.elems = s.elems[:len(s.elems)-1]
+}
+
+// String formats the path exactly as it would have been produced by
+// joining each element with keyPath and indexPath.
+func (s *pathStack) String() string {
+	if len(s.elems) == 0 {
+		return s.base
+	}
+	n := len(s.base)
+	for _, e := range s.elems {
+		if e.IsIndex() {
+			n += 2 + 20 // the widest int64
+		} else {
+			n += 1 + len(e.Key)
+		}
+	}
+	b := make([]byte, 0, n)
+	b = append(b, s.base...)
+	for _, e := range s.elems {
+		if e.IsIndex() {
+			b = append(b, '[')
+			b = strconv.AppendInt(b, int64(e.Index), 10)
+			b = append(b, ']')
+		} else {
+			if len(b) > 0 {
+				b = append(b, '.')
+			}
+			b = append(b, e.Key...)
+		}
+	}
+	return string(b)
+}
+
+// joinPath re-roots the path p beneath the path base.
+func joinPath(base, p string) string {
+	switch {
+	case p == "" || p == entityPath:
+		return coalesce(base, p)
+	case base == "":
+		return p
+	case p[0] == '[':
+		return base + p
+	default:
+		return keyPath(base, p)
+	}
+}
+
+// matchPath determines if the path p is at or beneath the query path q.
+// Index subscripts in q may be [*] wildcards, which match any index; a key
+// in q matches an alternates group in p when it is one of the alternates.
+func matchPath(q, p []pathElem) bool {
+	if len(p) < len(q) {
+		return false
+	}
+	for i, e := range q {
+		if !e.matches(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e pathElem) matches(x pathElem) bool {
+	if e.IsIndex() != x.IsIndex() {
+		return false
+	}
+	if e.IsIndex() {
+		return e.Index == anyIndex || e.Index == x.Index
+	}
+	if e.Key == x.Key {
+		return true
+	}
+	for _, k := range x.Alternates() {
+		if k == e.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// comparePaths orders paths component-wise, comparing keys lexically and
+// indexes numerically, so that "a[2]" sorts before "a[10]". Indexes sort
+// before keys and a path sorts before the paths beneath it.
+func comparePaths(a, b []pathElem) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := a[i], b[i]
+		switch {
+		case x.IsIndex() && !y.IsIndex():
+			return -1
+		case !x.IsIndex() && y.IsIndex():
+			return 1
+		case x.IsIndex():
+			if x.Index != y.Index {
+				return cmpInt(x.Index, y.Index)
+			}
+		default:
+			if c := strings.Compare(x.Key, y.Key); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(a), len(b))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
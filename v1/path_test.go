@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStack(t *testing.T) {
+	tests := []struct {
+		Base   string
+		Elems  []pathElem
+		Expect string
+	}{
+		{"", nil, ""},
+		{"root", nil, "root"},
+		{"", []pathElem{{Key: "a", Index: noIndex}}, "a"},
+		{"", []pathElem{{Index: 0}}, "[0]"},
+		{"root", []pathElem{{Key: "a", Index: noIndex}, {Index: 12}, {Key: "b", Index: noIndex}}, "root.a[12].b"},
+		{"root[1]", []pathElem{{Key: "", Index: noIndex}, {Key: "a", Index: noIndex}}, "root[1]..a"},
+		{"", []pathElem{{Key: "", Index: noIndex}, {Key: "a", Index: noIndex}}, "a"},
+	}
+	for _, e := range tests {
+		s := &pathStack{base: e.Base}
+		p := e.Base
+		for _, x := range e.Elems {
+			if x.IsIndex() {
+				s.pushIndex(x.Index)
+				p = indexPath(p, x.Index)
+			} else {
+				s.pushKey(x.Key)
+				p = keyPath(p, x.Key)
+			}
+		}
+		assert.Equal(t, e.Expect, s.String())
+		assert.Equal(t, p, s.String(), "must match the eagerly joined path")
+	}
+}
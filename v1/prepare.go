@@ -0,0 +1,148 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/bww/go-validate/v1/stdlib"
+)
+
+// invokesCheck matches expressions which recurse into the field's value
+var invokesCheck = regexp.MustCompile(`\bcheck\s*\(`)
+
+// invokesPattern matches invocations of str.Match and str.Pattern whose
+// first argument is a string literal
+var invokesPattern = regexp.MustCompile(`\bstr\.(Match|Pattern)\s*\(\s*("(?:[^"\\]|\\.)*")`)
+
+// Prepare eagerly prepares the provided types for validation, so that
+// configuration errors which would otherwise cause a panic the first time
+// a value is validated are reported up front. Each argument may be either
+// a value of the type to prepare or a [reflect.Type].
+//
+// Preparation walks the graph of each type, following pointers, slices,
+// arrays, embedded structs and fields whose checks recurse via check(self).
+// Every struct type encountered is described and every expression is
+// compiled, and the results are cached. Patterns which are provided to
+// str.Match as string literals are compiled and cached too, and the names
+// provided to str.Pattern must have been registered. All the problems that
+// are found are returned together as [Errors], in which each field error
+// identifies the path to the offending field. Slice and array elements are
+// described by the wildcard subscript [*]. Each type is prepared only once,
+// so the problems of a type which can be reached by several paths are
+// reported at the first path by which it was found.
+func (v Validator) Prepare(types ...interface{}) error {
+	p := &preparer{
+		Validator: v,
+		seen:      make(map[reflect.Type]struct{}),
+		errs:      &errorBuffer{},
+	}
+	for _, e := range types {
+		t, ok := e.(reflect.Type)
+		if !ok {
+			t = reflect.TypeOf(e)
+		}
+		p.prepare(v.basePath, t)
+	}
+	if len(p.errs.E) > 0 {
+		return Errors(p.errs.E)
+	}
+	return nil
+}
+
+type preparer struct {
+	Validator
+	seen map[reflect.Type]struct{}
+	errs *errorBuffer
+}
+
+func (p *preparer) prepare(path string, t reflect.Type) {
+	if t == nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		p.prepare(path, t.Elem())
+	case reflect.Slice, reflect.Array:
+		p.prepare(path+"[*]", t.Elem())
+	case reflect.Struct:
+		p.prepareStruct(path, t)
+	}
+}
+
+func (p *preparer) prepareStruct(path string, t reflect.Type) {
+	if _, ok := p.seen[t]; ok {
+		return
+	}
+	p.seen[t] = struct{}{}
+
+	// a type which implements only the first introspector interface is not
+	// validated field-by-field
+	if t.Implements(introspectorV1) && !t.Implements(introspectorV2) && !t.Implements(introspectorV3) {
+		return
+	}
+
+	vt, err := p.validatedType(t, p.errs)
+	if err != nil {
+		p.errs.Add(FieldErrorf(coalesce(path, entityPath), "%v: %v", t, err))
+		return
+	}
+
+	for _, e := range vt.Fields {
+		fpath := keyPath(path, e.Name)
+		switch {
+		case e.Unchecked:
+			continue
+		case e.Field.Anonymous:
+			p.prepare(fpath, e.Field.Type)
+			continue
+		case e.Expr == "check":
+			p.prepare(fpath, e.Field.Type)
+			continue
+		case !e.Field.IsExported():
+			p.errs.Add(FieldErrorf(fpath, "%v.%s: Cannot validate unexported field", t, e.Field.Name))
+			continue
+		}
+		_, err := p.compile(e.Expr, p.errs)
+		if err != nil {
+			p.errs.Add(&FieldError{
+				Field:   fpath,
+				Message: fmt.Sprintf("%v.%s: Could not compile expression: %v", t, e.Field.Name, err),
+				Cause:   err,
+			})
+			continue
+		}
+		p.preparePatterns(fpath, t, e.Field.Name, e.Expr)
+		if invokesCheck.MatchString(e.Expr) {
+			p.prepare(fpath, e.Field.Type)
+		}
+	}
+}
+
+// preparePatterns compiles the literal patterns an expression provides to
+// str.Match, so they are cached, and verifies that the names it provides to
+// str.Pattern have been registered.
+func (p *preparer) preparePatterns(fpath string, t reflect.Type, name, src string) {
+	for _, m := range invokesPattern.FindAllStringSubmatch(src, -1) {
+		arg, err := strconv.Unquote(m[2])
+		if err != nil {
+			continue // an escape which EPL accepts but Go does not; it's checked when evaluated
+		}
+		switch m[1] {
+		case "Match":
+			_, err = p.Cache().pattern(arg, p.errs)
+			if err != nil {
+				p.errs.Add(&FieldError{
+					Field:   fpath,
+					Message: fmt.Sprintf("%v.%s: Invalid pattern: %v", t, name, err),
+					Cause:   err,
+				})
+			}
+		case "Pattern":
+			if _, ok := stdlib.RegisteredPattern(arg); !ok {
+				p.errs.Add(FieldErrorf(fpath, "%v.%s: Unknown pattern: %s", t, name, arg))
+			}
+		}
+	}
+}
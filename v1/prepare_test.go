@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type prepA struct {
+	F1 string  `json:"a_1" check:"len(self) > "`
+	F2 []prepB `json:"a_2" check:"check(self)"`
+	F3 *prepA  `json:"a_3" check:"self == nil || check(self)"`
+	f4 string  `check:"len(self) > 0"`
+}
+
+type prepB struct {
+	F1 int `json:"b_1" check:"self >= 0" severity:"sometimes"`
+}
+
+type prepC struct {
+	prepD
+	F1 map[string]int `json:"c_1" check:"self != nil && (("`
+}
+
+type prepD struct {
+	F1 int `json:"d_1" check:"self &&& 1"`
+}
+
+type prepE struct {
+	F1 string `json:"e_1" check:"str.Match(\"^[a-z]+$\", self)"`
+	F2 string `json:"e_2" check:"str.Match(\"^[a-z+$\", self)"`
+	F3 string `json:"e_3" check:"str.Pattern(\"prep-sku\", self)"`
+	F4 string `json:"e_4" check:"len(self) == 0 || str.Pattern(\"prep-unknown\", self)"`
+}
+
+func TestPrepare(t *testing.T) {
+	v := New(PrivateCache(CacheConfig{ExprSize: 16, TypeSize: 16}))
+
+	assert.NoError(t, v.Prepare(testA{}, testB{}, &testH{}, testU{}, reflect.TypeOf(testT{})))
+	assert.Equal(t, 4, v.Cache().Stats().Types.Len) // testA, testB, testH, testT
+
+	err := v.Prepare(prepA{}, prepC{})
+	if assert.Error(t, err) {
+		errs := err.(Errors)
+		assert.Equal(t, []string{"a_1", "a_2[*]", "f4", "d_1", "c_1"}, errs.Fields()) // prepA is also reached by a_3, but reported once
+	}
+}
+
+func init() {
+	// registered once per process, since the registry is global and tests may run more than once
+	if err := RegisterPattern("prep-sku", `^[A-Z]{3}-[0-9]{4}$`); err != nil {
+		panic(err)
+	}
+}
+
+func TestPreparePatterns(t *testing.T) {
+	assert.Error(t, RegisterPattern("prep-sku", `^[A-Z]+$`))
+
+	v := New(PrivateCache(CacheConfig{ExprSize: 16, TypeSize: 16, PatternSize: 16}))
+	err := v.Prepare(prepE{})
+	if assert.Error(t, err) {
+		errs := err.(Errors)
+		assert.Equal(t, []string{"e_2", "e_4"}, errs.Fields())
+	}
+	assert.Equal(t, 1, v.Cache().Stats().Patterns.Len)
+
+	assert.Panics(t, func() { v.Validate(prepE{F1: "a", F3: "ABC-1234"}) }) // as it was found to be misconfigured
+}
@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Severity describes how a failed check is reported.
+type Severity int
+
+const (
+	// SeverityError is the default severity; failed checks are reported as
+	// errors and make the validated value invalid.
+	SeverityError Severity = iota
+	// SeverityWarning describes a soft constraint; failed checks are reported
+	// as warnings and do not make the validated value invalid.
+	SeverityWarning
+)
+
+func parseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "error":
+		return SeverityError, nil
+	case "warn", "warning":
+		return SeverityWarning, nil
+	default:
+		return SeverityError, fmt.Errorf("Unsupported severity: %q", s)
+	}
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warn"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Result is the outcome of validating a value, separating errors, which
+// make the value invalid, from warnings, which do not.
+type Result struct {
+	Errors   Errors
+	Warnings Errors
+}
+
+// Valid determines if the result contains no errors. A valid result may
+// still contain warnings.
+func (r Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Deprecation describes the use of a deprecated field.
+type Deprecation struct {
+	Type    reflect.Type // the struct type which declares the field
+	Field   string       // the Go name of the field
+	Path    string       // the path to the field in the validated value
+	Message string       // the deprecation message, if any
+}
+
+// DeprecationHandler is invoked when a deprecated field is set.
+type DeprecationHandler func(Deprecation)
@@ -0,0 +1,391 @@
+package stdlib
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coll validates the elements of slices, arrays and maps. It is available
+// to expressions as coll.
+//
+// The elements of a map are its values. Elements are equal when they are
+// numbers with the same value, strings with the same content, or are
+// otherwise deeply equal; pointers are compared by the values they refer
+// to. A value which is not a collection is a configuration error, except
+// for nil, which is empty.
+type Coll struct{}
+
+// Unique reports whether no two elements of c are equal
+func (v Coll) Unique(c interface{}) bool {
+	var s set
+	ok := true
+	each(collection(c), func(e reflect.Value) bool {
+		ok = s.add(e)
+		return ok
+	})
+	return ok
+}
+
+// UniqueBy reports whether no two elements of c have an equal value for the
+// named field, which may be a path like Item.SKU. Elements which are maps
+// are indexed by the name instead. Nil elements are ignored.
+func (v Coll) UniqueBy(c interface{}, field string) bool {
+	var s set
+	ok := true
+	each(collection(c), func(e reflect.Value) bool {
+		if f, present := member(e, field); present {
+			ok = s.add(f)
+		}
+		return ok
+	})
+	return ok
+}
+
+// Contains reports whether an element of c is equal to x
+func (v Coll) Contains(c, x interface{}) bool {
+	var found bool
+	z := reflect.ValueOf(x)
+	each(collection(c), func(e reflect.Value) bool {
+		found = equal(e, z)
+		return !found
+	})
+	return found
+}
+
+// Subset reports whether every element of c is equal to an element of
+// allowed, which is itself a collection.
+func (v Coll) Subset(c, allowed interface{}) bool {
+	var s set
+	each(collection(allowed), func(e reflect.Value) bool {
+		s.add(e)
+		return true
+	})
+	ok := true
+	each(collection(c), func(e reflect.Value) bool {
+		ok = s.has(e)
+		return ok
+	})
+	return ok
+}
+
+// Sorted reports whether the elements of c, which must be a slice or an
+// array of numbers, strings or times, are in ascending order. Elements
+// which cannot be ordered are a configuration error.
+func (v Coll) Sorted(c interface{}) bool {
+	z := collection(c)
+	if z.Kind() == reflect.Map {
+		panic(fmt.Errorf("validate: Maps are not ordered: %v", z.Type()))
+	}
+	for i := 1; i < length(z); i++ {
+		if mustCompare(z.Index(i-1), z.Index(i)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns the keys of the map c, in ascending order when they can be
+// ordered, so that they can be validated as a collection, as in
+// coll.Subset(coll.Keys(self), sup.Allowed)
+func (v Coll) Keys(c interface{}) []interface{} {
+	z := collection(c)
+	if z.IsValid() && z.Kind() != reflect.Map {
+		panic(fmt.Errorf("validate: Not a map: %v", z.Type()))
+	}
+	k := make([]interface{}, 0, length(z))
+	if !z.IsValid() {
+		return k
+	}
+	keys := z.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		n, ok := compare(keys[i], keys[j])
+		if !ok {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		}
+		return n < 0
+	})
+	for _, e := range keys {
+		k = append(k, e.Interface())
+	}
+	return k
+}
+
+// HasKeys reports whether the map c has every one of the provided keys
+func (v Coll) HasKeys(c interface{}, keys ...interface{}) bool {
+	var s set
+	for _, e := range v.Keys(c) {
+		s.add(reflect.ValueOf(e))
+	}
+	for _, e := range keys {
+		if !s.has(reflect.ValueOf(e)) {
+			return false
+		}
+	}
+	return true
+}
+
+// MinLen reports whether c has at least n elements. A failed check which
+// consists of MinLen, MaxLen or both, as in coll.MinLen(self, 1) &&
+// coll.MaxLen(self, 10), is reported with a message that describes it,
+// like: Must have between 1 and 10 items; see [Message].
+func (v Coll) MinLen(c interface{}, n float64) bool {
+	return length(collection(c)) >= count(n)
+}
+
+// MaxLen reports whether c has at most n elements. Like MinLen, a failed
+// check is reported as: Must have at most 10 items.
+func (v Coll) MaxLen(c interface{}, n float64) bool {
+	return length(collection(c)) <= count(n)
+}
+
+// collLen matches a call which limits the length of the value being
+// validated
+var collLen = regexp.MustCompile(`^coll\.(MinLen|MaxLen)\(\s*self\s*,\s*([0-9]+)\s*\)$`)
+
+// lenMessage describes the failure of an expression which consists of
+// calls to MinLen and MaxLen, or produces the empty string
+func lenMessage(expr string) string {
+	min, max := -1, -1
+	for _, e := range strings.Split(expr, "&&") {
+		m := collLen.FindStringSubmatch(strings.TrimSpace(e))
+		if m == nil {
+			return ""
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return ""
+		}
+		switch {
+		case m[1] == "MinLen" && min < 0:
+			min = n
+		case m[1] == "MaxLen" && max < 0:
+			max = n
+		default:
+			return ""
+		}
+	}
+	switch {
+	case min >= 0 && max >= 0:
+		return fmt.Sprintf("Must have between %d and %s", min, items(max))
+	case min >= 0:
+		return fmt.Sprintf("Must have at least %s", items(min))
+	default:
+		return fmt.Sprintf("Must have at most %s", items(max))
+	}
+}
+
+func items(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
+
+func count(n float64) int {
+	if n < 0 || n != float64(int(n)) {
+		panic(fmt.Errorf("validate: Invalid number of elements: %v", n))
+	}
+	return int(n)
+}
+
+// collection dereferences c, which must be a slice, array or map, and
+// returns the invalid value if it is nil.
+func collection(c interface{}) reflect.Value {
+	z := indirect(reflect.ValueOf(c))
+	switch z.Kind() {
+	case reflect.Invalid, reflect.Slice, reflect.Array, reflect.Map:
+		return z
+	default:
+		panic(fmt.Errorf("validate: Not a collection: %v", z.Type()))
+	}
+}
+
+func length(z reflect.Value) int {
+	if !z.IsValid() {
+		return 0
+	}
+	return z.Len()
+}
+
+// each invokes f for every element of z until f returns false
+func each(z reflect.Value, f func(reflect.Value) bool) {
+	switch z.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < z.Len(); i++ {
+			if !f(z.Index(i)) {
+				return
+			}
+		}
+	case reflect.Map:
+		for r := z.MapRange(); r.Next(); {
+			if !f(r.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// indirect dereferences pointers and interfaces, returning the invalid
+// value if any of them is nil. Pointers to big numbers are retained, since
+// that is how they are used.
+func indirect(z reflect.Value) reflect.Value {
+	for (z.Kind() == reflect.Pointer && !bigTypes[z.Type()]) || z.Kind() == reflect.Interface {
+		if z.IsNil() {
+			return reflect.Value{}
+		}
+		z = z.Elem()
+	}
+	return z
+}
+
+// member obtains the named member of a struct or map, reporting whether
+// it is present. A struct which has no such field is a configuration error.
+func member(z reflect.Value, path string) (reflect.Value, bool) {
+	for _, n := range strings.Split(path, ".") {
+		z = indirect(z)
+		switch z.Kind() {
+		case reflect.Invalid:
+			return z, false
+		case reflect.Struct:
+			f, ok := z.Type().FieldByName(n)
+			if !ok || !f.IsExported() {
+				panic(fmt.Errorf("validate: No such field: %v.%s", z.Type(), n))
+			}
+			z = z.FieldByIndex(f.Index)
+		case reflect.Map:
+			if z.Type().Key().Kind() != reflect.String {
+				panic(fmt.Errorf("validate: Map keys are not strings: %v", z.Type()))
+			}
+			z = z.MapIndex(reflect.ValueOf(n).Convert(z.Type().Key()))
+			if !z.IsValid() {
+				return z, false
+			}
+		default:
+			panic(fmt.Errorf("validate: Cannot obtain field %s of %v", n, z.Type()))
+		}
+	}
+	return z, true
+}
+
+var bigTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*big.Int)(nil)):   true,
+	reflect.TypeOf((*big.Float)(nil)): true,
+	reflect.TypeOf((*big.Rat)(nil)):   true,
+}
+
+type (
+	nilKey  struct{}
+	numKey  string
+	strKey  string
+	timeKey struct {
+		sec  int64
+		nsec int
+	}
+)
+
+// key returns a comparable value which is equal for equal values, or
+// false if z is not comparable.
+func key(z reflect.Value) (interface{}, bool) {
+	z = indirect(z)
+	switch z.Kind() {
+	case reflect.Invalid:
+		return nilKey{}, true
+	case reflect.String:
+		return strKey(z.String()), true
+	}
+	x := z.Interface()
+	if t, ok := x.(time.Time); ok {
+		return timeKey{t.Unix(), t.Nanosecond()}, true // the same instant in any location
+	}
+	if r, ok := number(x); ok {
+		return numKey(r.RatString()), true
+	}
+	if !z.Comparable() {
+		return nil, false
+	}
+	return z.Interface(), true
+}
+
+func equal(a, b reflect.Value) bool {
+	x, ok := key(a)
+	y, yok := key(b)
+	if ok && yok {
+		return x == y
+	}
+	a, b = indirect(a), indirect(b)
+	return a.IsValid() && b.IsValid() && reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// set is a set of values which are compared as equal does
+type set struct {
+	keys  map[interface{}]struct{}
+	other []reflect.Value // values which are not comparable
+}
+
+// add adds a value to the set, reporting whether it was not already present
+func (s *set) add(z reflect.Value) bool {
+	if s.has(z) {
+		return false
+	}
+	if k, ok := key(z); ok {
+		if s.keys == nil {
+			s.keys = make(map[interface{}]struct{})
+		}
+		s.keys[k] = struct{}{}
+	} else {
+		s.other = append(s.other, z)
+	}
+	return true
+}
+
+func (s *set) has(z reflect.Value) bool {
+	if k, ok := key(z); ok {
+		_, found := s.keys[k]
+		return found
+	}
+	for _, e := range s.other {
+		if equal(e, z) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare orders two numbers, strings or times, reporting whether they
+// can be ordered
+func compare(a, b reflect.Value) (int, bool) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+	x, y := a.Interface(), b.Interface()
+	if s, ok := x.(time.Time); ok {
+		if t, ok := y.(time.Time); ok {
+			return s.Compare(t), true
+		}
+		return 0, false
+	}
+	if r, ok := number(x); ok {
+		if s, ok := number(y); ok {
+			return r.Cmp(s), true
+		}
+	}
+	return 0, false
+}
+
+func mustCompare(a, b reflect.Value) int {
+	n, ok := compare(a, b)
+	if !ok {
+		panic(fmt.Errorf("validate: Cannot order %v and %v", a, b))
+	}
+	return n
+}
@@ -0,0 +1,145 @@
+package stdlib
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type collItem struct {
+	SKU  string
+	Qty  int
+	Item *collItem
+}
+
+func TestCollUnique(t *testing.T) {
+	v := Coll{}
+	s, u := "a", "a"
+	tests := []struct {
+		In     interface{}
+		Expect bool
+	}{
+		{nil, true},
+		{[]string(nil), true},
+		{[]string{}, true},
+		{[]string{"a", "b", "c"}, true},
+		{[]string{"a", "b", "a"}, false},
+		{[3]int{1, 2, 3}, true},
+		{&[]int{1, 2, 1}, false},
+		{[]interface{}{1, 1.0}, false},
+		{[]interface{}{1, "1"}, true},
+		{[]interface{}{int8(2), uint64(2)}, false},
+		{[]interface{}{nil, nil}, false},
+		{[]*string{&s, &u}, false},
+		{[]*big.Int{big.NewInt(1), big.NewInt(2)}, true},
+		{[]interface{}{big.NewInt(1), big.NewRat(2, 2)}, false},
+		{[]time.Time{time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 13, 0, 0, 0, time.FixedZone("", 3600))}, false},
+		{[][]int{{1, 2}, {2, 1}}, true},
+		{[][]int{{1, 2}, {1, 2}}, false},
+		{map[string]int{"a": 1, "b": 2}, true},
+		{map[string]int{"a": 1, "b": 1}, false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Expect, v.Unique(e.In), "%#v", e.In)
+	}
+	assert.Panics(t, func() { v.Unique("abc") })
+	assert.Panics(t, func() { v.Unique(1) })
+}
+
+func TestCollUniqueBy(t *testing.T) {
+	v := Coll{}
+	assert.True(t, v.UniqueBy([]collItem{{SKU: "a"}, {SKU: "b"}}, "SKU"))
+	assert.False(t, v.UniqueBy([]collItem{{SKU: "a", Qty: 1}, {SKU: "a", Qty: 2}}, "SKU"))
+	assert.True(t, v.UniqueBy([]*collItem{{SKU: "a"}, nil, nil, {SKU: "b"}}, "SKU"))
+	assert.False(t, v.UniqueBy([]*collItem{{Item: &collItem{SKU: "a"}}, {Item: &collItem{SKU: "a"}}}, "Item.SKU"))
+	assert.True(t, v.UniqueBy([]*collItem{{Item: &collItem{SKU: "a"}}, {}}, "Item.SKU"))
+	assert.True(t, v.UniqueBy([]map[string]interface{}{{"id": 1}, {"id": 2}, {}}, "id"))
+	assert.False(t, v.UniqueBy([]map[string]interface{}{{"id": 1}, {"id": 1.0}}, "id"))
+	assert.Panics(t, func() { v.UniqueBy([]collItem{{}}, "Missing") })
+	assert.Panics(t, func() { v.UniqueBy([]collItem{{}}, "SKU.Missing") })
+	assert.Panics(t, func() { v.UniqueBy([]map[int]int{{1: 1}}, "1") })
+}
+
+func TestCollMembership(t *testing.T) {
+	v := Coll{}
+	assert.True(t, v.Contains([]string{"a", "b"}, "b"))
+	assert.False(t, v.Contains([]string{"a", "b"}, "c"))
+	assert.False(t, v.Contains(nil, "a"))
+	assert.True(t, v.Contains([]int{1, 2, 3}, 2.0))
+	assert.True(t, v.Contains(map[string]string{"x": "a"}, "a"))
+	assert.False(t, v.Contains(map[string]string{"x": "a"}, "x"))
+
+	allowed := []interface{}{"usd", "eur", "gbp"}
+	assert.True(t, v.Subset([]string{"usd", "eur", "usd"}, allowed))
+	assert.True(t, v.Subset([]string{}, allowed))
+	assert.True(t, v.Subset(nil, allowed))
+	assert.False(t, v.Subset([]string{"usd", "jpy"}, allowed))
+	assert.False(t, v.Subset([]string{"usd"}, nil))
+	assert.True(t, v.Subset([][]int{{1}}, [][]int{{2}, {1}}))
+	assert.Panics(t, func() { v.Subset([]string{"usd"}, "usd") })
+}
+
+func TestCollOrder(t *testing.T) {
+	v := Coll{}
+	assert.True(t, v.Sorted(nil))
+	assert.True(t, v.Sorted([]int{}))
+	assert.True(t, v.Sorted([]int{1, 1, 2, 3}))
+	assert.False(t, v.Sorted([]int{1, 3, 2}))
+	assert.True(t, v.Sorted([]string{"a", "b", "c"}))
+	assert.False(t, v.Sorted([2]string{"b", "a"}))
+	assert.True(t, v.Sorted([]interface{}{1, 1.5, "2", big.NewInt(3)}))
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, v.Sorted([]time.Time{at, at.Add(time.Hour)}))
+	assert.False(t, v.Sorted([]time.Time{at, at.Add(-time.Hour)}))
+	assert.Panics(t, func() { v.Sorted(map[string]int{"a": 1}) })
+	assert.Panics(t, func() { v.Sorted([]interface{}{1, "a"}) })
+	assert.Panics(t, func() { v.Sorted([]bool{true, false}) })
+}
+
+func TestCollKeys(t *testing.T) {
+	v := Coll{}
+	assert.Equal(t, []interface{}{"a", "b", "c"}, v.Keys(map[string]int{"c": 3, "a": 1, "b": 2}))
+	assert.Equal(t, []interface{}{1, 2, 10}, v.Keys(map[int]bool{10: true, 2: true, 1: true}))
+	assert.Equal(t, []interface{}{}, v.Keys(map[string]int(nil)))
+	assert.Equal(t, []interface{}{}, v.Keys(nil))
+	assert.Panics(t, func() { v.Keys([]string{"a"}) })
+
+	assert.True(t, v.HasKeys(map[string]int{"a": 1, "b": 2}, "a", "b"))
+	assert.True(t, v.HasKeys(map[string]int{"a": 1}))
+	assert.False(t, v.HasKeys(map[string]int{"a": 1}, "a", "b"))
+	assert.False(t, v.HasKeys(nil, "a"))
+	assert.True(t, v.HasKeys(map[int]int{1: 1}, 1.0))
+}
+
+func TestCollLen(t *testing.T) {
+	v := Coll{}
+	assert.True(t, v.MinLen([]int{1, 2}, 2))
+	assert.False(t, v.MinLen([]int{1}, 2))
+	assert.False(t, v.MinLen(nil, 1))
+	assert.True(t, v.MinLen(nil, 0))
+	assert.True(t, v.MaxLen(map[string]int{"a": 1}, 1))
+	assert.False(t, v.MaxLen(map[string]int{"a": 1, "b": 2}, 1))
+	assert.False(t, v.MaxLen([3]int{}, 2))
+	assert.Panics(t, func() { v.MinLen([]int{}, -1) })
+	assert.Panics(t, func() { v.MaxLen([]int{}, 1.5) })
+	assert.Panics(t, func() { v.MaxLen("abc", 1) })
+
+	for _, e := range []struct {
+		Expr, Message string
+	}{
+		{"coll.MinLen(self, 1)", "Must have at least 1 item"},
+		{"coll.MinLen(self, 2)", "Must have at least 2 items"},
+		{"coll.MaxLen(self,10)", "Must have at most 10 items"},
+		{"coll.MinLen(self, 1) && coll.MaxLen(self, 5)", "Must have between 1 and 5 items"},
+		{"coll.MaxLen(self, 5) && coll.MinLen(self, 0)", "Must have between 0 and 5 items"},
+		{"coll.MinLen(self, 1) && coll.MinLen(self, 2)", ""},
+		{"self == nil || coll.MinLen(self, 1)", ""},
+		{"coll.MinLen(sup.Items, 1)", ""},
+		{"coll.Unique(self)", ""},
+		{"len(self) > 0", ""},
+	} {
+		assert.Equal(t, e.Message, Message(e.Expr), e.Expr)
+	}
+}
@@ -0,0 +1,215 @@
+package stdlib
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Fin validates financial values: payment card numbers, IBANs, BICs and
+// amounts of money. It is available to expressions as fin.
+type Fin struct{}
+
+// Luhn reports whether s is a string of at least two digits, the last of
+// which is the Luhn check digit of the others
+func (v Fin) Luhn(s string) bool {
+	return len(s) > 1 && luhn(s)
+}
+
+// Card reports whether s is a payment card number: 12 to 19 digits, which
+// may be separated by single spaces or hyphens, with a valid Luhn check
+// digit and the prefix and length of a known brand. If any brands are
+// provided, as in fin.Card(self, "visa", "mastercard"), the number must be
+// of one of them. Brands are named as CardBrand produces them, and a brand
+// which is not one of those is a configuration error.
+func (v Fin) Card(s string, brands ...interface{}) bool {
+	b := v.CardBrand(s)
+	for _, e := range brands {
+		n, ok := e.(string)
+		if !ok || !cardBrands[n] {
+			panic(fmt.Errorf("validate: Unsupported card brand: %v", e))
+		}
+	}
+	if b == "" || len(brands) == 0 {
+		return b != ""
+	}
+	for _, e := range brands {
+		if e == b {
+			return true
+		}
+	}
+	return false
+}
+
+// CardBrand produces the brand of the payment card number s, which is one
+// of amex, diners, discover, jcb, maestro, mastercard, mir, unionpay or
+// visa, as in fin.CardBrand(self) == "visa". It produces the empty string
+// if s is not a card number of a known brand, as Card describes.
+func (v Fin) CardBrand(s string) string {
+	d, ok := separated(s, true)
+	if !ok || len(d) < 12 || len(d) > 19 || !luhn(d) {
+		return ""
+	}
+	var match *cardRange
+	for i, e := range cardRanges {
+		p := d[:len(e.lo)]
+		if p >= e.lo && p <= e.hi && (match == nil || len(e.lo) > len(match.lo)) {
+			match = &cardRanges[i]
+		}
+	}
+	if match == nil || !match.length(len(d)) {
+		return ""
+	}
+	return match.brand
+}
+
+// IBAN reports whether s is an International Bank Account Number with the
+// length which is registered for its country and valid check digits. It
+// may be written in groups of four characters separated by single spaces,
+// as in GB82 WEST 1234 5698 7654 32
+func (v Fin) IBAN(s string) bool {
+	if strings.IndexByte(s, ' ') >= 0 {
+		g := strings.Split(s, " ")
+		for i, e := range g {
+			if len(e) == 0 || len(e) > 4 || (len(e) < 4 && i < len(g)-1) {
+				return false
+			}
+		}
+		s = strings.Join(g, "")
+	}
+	n, ok := ibanLengths[s[:min(len(s), 2)]]
+	if !ok || len(s) != n || !numeric(s[2:4]) {
+		return false
+	}
+	r := 0
+	for _, c := range s[4:] + s[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			r = (r*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			r = (r*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return r == 1
+}
+
+// BIC reports whether s is a Business Identifier Code, also known as a
+// SWIFT code: four letters identifying a bank, the ISO 3166-1 alpha-2 code
+// of a country, two letters or digits identifying a location and
+// optionally three identifying a branch, like DEUTDEFF500
+func (v Fin) BIC(s string) bool {
+	if !bic.MatchString(s) {
+		return false
+	}
+	_, ok := countries[s[4:6]]
+	return ok || s[4:6] == "XK" // Kosovo is not assigned a code by ISO 3166
+}
+
+// Amount reports whether x is a number with no more decimals than the
+// minor units of the ISO 4217 currency, as in fin.Amount(self, sup.Currency),
+// so 12.50 is an amount of USD but not of JPY. Decimal strings are
+// considered as they are written, as with num.MaxDecimals. Any number is
+// an amount of a currency which has no minor units, like XAU, and no
+// number is an amount of an unknown currency.
+func (v Fin) Amount(x interface{}, currency string) bool {
+	n, ok := currencies[currency]
+	if !ok {
+		return false
+	}
+	if n < 0 {
+		_, ok = number(x)
+		return ok
+	}
+	d, ok := decimals(x)
+	return ok && d <= n
+}
+
+var bic = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}(?:[A-Z0-9]{3})?$`)
+
+//go:embed data/iban.txt
+var iban string
+
+var ibanLengths = make(map[string]int)
+
+func init() {
+	table(iban, 2, func(f []string) {
+		n, err := strconv.Atoi(f[1])
+		if err != nil {
+			panic(fmt.Errorf("validate: Invalid IBAN length: %v", f))
+		}
+		ibanLengths[f[0]] = n
+	})
+}
+
+// luhn reports whether the last of the digits s is the Luhn check digit
+// of the others
+func luhn(s string) bool {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		c := s[len(s)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		n := int(c - '0')
+		if i%2 == 1 {
+			if n *= 2; n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// cardRange is a range of prefixes which identify the brand of a card,
+// inclusive, and the lengths of its card numbers; where ranges overlap,
+// the longest prefix identifies the brand
+type cardRange struct {
+	brand    string
+	lo, hi   string
+	min, max int
+	step     int
+}
+
+func (r cardRange) length(n int) bool {
+	return n >= r.min && n <= r.max && (n-r.min)%r.step == 0
+}
+
+var cardRanges = []cardRange{
+	{"amex", "34", "34", 15, 15, 1},
+	{"amex", "37", "37", 15, 15, 1},
+	{"diners", "300", "305", 14, 19, 1},
+	{"diners", "3095", "3095", 14, 19, 1},
+	{"diners", "36", "36", 14, 19, 1},
+	{"diners", "38", "39", 14, 19, 1},
+	{"discover", "6011", "6011", 16, 19, 1},
+	{"discover", "622126", "622925", 16, 19, 1},
+	{"discover", "644", "649", 16, 19, 1},
+	{"discover", "65", "65", 16, 19, 1},
+	{"jcb", "3528", "3589", 16, 19, 1},
+	{"maestro", "5018", "5018", 12, 19, 1},
+	{"maestro", "5020", "5020", 12, 19, 1},
+	{"maestro", "5038", "5038", 12, 19, 1},
+	{"maestro", "5893", "5893", 12, 19, 1},
+	{"maestro", "6304", "6304", 12, 19, 1},
+	{"maestro", "6759", "6759", 12, 19, 1},
+	{"maestro", "6761", "6763", 12, 19, 1},
+	{"mastercard", "2221", "2720", 16, 16, 1},
+	{"mastercard", "51", "55", 16, 16, 1},
+	{"mir", "2200", "2204", 16, 19, 1},
+	{"unionpay", "62", "62", 16, 19, 1},
+	{"unionpay", "81", "81", 16, 19, 1},
+	{"visa", "4", "4", 13, 19, 3},
+}
+
+var cardBrands = func() map[string]bool {
+	b := make(map[string]bool)
+	for _, e := range cardRanges {
+		b[e.brand] = true
+	}
+	return b
+}()
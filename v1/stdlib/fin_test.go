@@ -0,0 +1,113 @@
+package stdlib
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinCards(t *testing.T) {
+	v := Fin{}
+	tests := []struct {
+		In    string
+		Brand string
+	}{
+		{"4111111111111111", "visa"},
+		{"4111 1111 1111 1111", "visa"},
+		{"4111-1111-1111-1111", "visa"},
+		{"4222222222222", "visa"},
+		{"4111111111111111110", "visa"},
+		{"5555555555554444", "mastercard"},
+		{"2223003122003222", "mastercard"},
+		{"378282246310005", "amex"},
+		{"6011111111111117", "discover"},
+		{"6221260000000000", "discover"},
+		{"30569309025904", "diners"},
+		{"38520000023237", "diners"},
+		{"3530111333300000", "jcb"},
+		{"6200000000000005", "unionpay"},
+		{"6759649826438453", "maestro"},
+		{"5018000000000009", "maestro"},
+		{"2200000000000004", "mir"},
+		{"", ""},
+		{"4111111111111112", ""},    // the check digit is wrong
+		{"411111111111111", ""},     // visa numbers are 13, 16 or 19 digits
+		{"4111  1111111111111", ""}, // separators are single
+		{"4111111111111111 ", ""},
+		{"9111111111111116", ""}, // an unknown brand
+		{"41111111111X", ""},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Brand, v.CardBrand(e.In), "CardBrand: %s", e.In)
+		assert.Equal(t, e.Brand != "", v.Card(e.In), "Card: %s", e.In)
+	}
+	assert.True(t, v.Card("4111111111111111", "visa", "mastercard"))
+	assert.False(t, v.Card("378282246310005", "visa", "mastercard"))
+	assert.False(t, v.Card("4111111111111112", "visa"))
+	assert.Panics(t, func() { v.Card("4111111111111111", "Visa") })
+	assert.Panics(t, func() { v.Card("4111111111111111", 4) })
+
+	assert.True(t, v.Luhn("79927398713"))
+	assert.True(t, v.Luhn("00"))
+	assert.False(t, v.Luhn("79927398710"))
+	assert.False(t, v.Luhn("0"))
+	assert.False(t, v.Luhn("7992 7398 713"))
+}
+
+func TestFinBanks(t *testing.T) {
+	v := Fin{}
+	assert.True(t, v.IBAN("GB82WEST12345698765432"))
+	assert.True(t, v.IBAN("GB82 WEST 1234 5698 7654 32"))
+	assert.True(t, v.IBAN("DE89370400440532013000"))
+	assert.True(t, v.IBAN("NO9386011117947"))
+	assert.True(t, v.IBAN("FR1420041010050500013M02606"))
+	assert.False(t, v.IBAN(""))
+	assert.False(t, v.IBAN("GB"))
+	assert.False(t, v.IBAN("GB82WEST12345698765431"))     // the check digits are wrong
+	assert.False(t, v.IBAN("GB82WEST1234569876543"))      // too short for GB
+	assert.False(t, v.IBAN("US82WEST12345698765432"))     // the US does not use IBANs
+	assert.False(t, v.IBAN("gb82west12345698765432"))     // lower case
+	assert.False(t, v.IBAN("GB82 WEST 1234 5698 765432")) // irregular groups
+	assert.False(t, v.IBAN("GB82  WEST 1234 5698 7654 32"))
+	assert.False(t, v.IBAN("GB82WEST12345698765432 "))
+	assert.False(t, v.IBAN("GBX2WEST12345698765432"))
+
+	assert.True(t, v.BIC("DEUTDEFF"))
+	assert.True(t, v.BIC("DEUTDEFF500"))
+	assert.True(t, v.BIC("NEDSZAJJXXX"))
+	assert.True(t, v.BIC("RBKOXKPR"))
+	assert.False(t, v.BIC("DEUTZZFF")) // ZZ is not a country
+	assert.False(t, v.BIC("DEUT1EFF"))
+	assert.False(t, v.BIC("deutdeff"))
+	assert.False(t, v.BIC("DEUTDEFF50"))
+	assert.False(t, v.BIC("DEUTDEFF5000"))
+}
+
+func TestFinAmounts(t *testing.T) {
+	v := Fin{}
+	tests := []struct {
+		In       interface{}
+		Currency string
+		Expect   bool
+	}{
+		{12.5, "USD", true},
+		{"12.50", "USD", true},
+		{"12.505", "USD", false},
+		{12.505, "USD", false},
+		{100, "JPY", true},
+		{"100", "JPY", true},
+		{"100.0", "JPY", false},
+		{0.5, "JPY", false},
+		{"1.234", "KWD", true},
+		{"1.2345", "KWD", false},
+		{"1.2345", "CLF", true},
+		{big.NewRat(1, 3), "XAU", true},
+		{"1.5", "usd", false},
+		{"1.5", "ZZZ", false},
+		{"abc", "USD", false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Expect, v.Amount(e.In, e.Currency), "Amount: %v %s", e.In, e.Currency)
+	}
+}
@@ -0,0 +1,217 @@
+package stdlib
+
+import (
+	"unicode"
+)
+
+// graphemes counts the extended grapheme clusters of s, the characters a
+// reader perceives, as described by Unicode Standard Annex #29. The
+// properties of characters are derived from the unicode package; spacing
+// marks are approximated by the general category Mc, pictographs by the
+// emoji blocks, and the rule which joins Indic conjuncts is not applied.
+func graphemes(s string) int {
+	var n, ri int
+	prev := gbNone
+	pict := false // whether the characters so far end with a pictograph and Extend* or ZWJ
+	for _, r := range s {
+		c := graphemeBreak(r)
+		if graphemeBoundary(prev, c, pict, ri) {
+			n++
+		}
+		switch {
+		case c == gbPictographic:
+			pict = true
+		case c == gbExtend || c == gbZWJ:
+			// preserved
+		default:
+			pict = false
+		}
+		if c == gbRegionalIndicator {
+			ri++
+		} else {
+			ri = 0
+		}
+		prev = c
+	}
+	return n
+}
+
+type gbClass int
+
+const (
+	gbNone gbClass = iota
+	gbOther
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbPictographic
+)
+
+// graphemeBoundary reports whether there is a boundary between characters
+// of the classes a and b; pict reports whether a ends a pictograph and any
+// Extend or ZWJ characters which follow it, and ri is the number of
+// consecutive regional indicators which precede b
+func graphemeBoundary(a, b gbClass, pict bool, ri int) bool {
+	switch {
+	case a == gbNone: // GB1
+		return true
+	case a == gbCR && b == gbLF: // GB3
+		return false
+	case a == gbCR || a == gbLF || a == gbControl: // GB4
+		return true
+	case b == gbCR || b == gbLF || b == gbControl: // GB5
+		return true
+	case a == gbL && (b == gbL || b == gbV || b == gbLV || b == gbLVT): // GB6
+		return false
+	case (a == gbLV || a == gbV) && (b == gbV || b == gbT): // GB7
+		return false
+	case (a == gbLVT || a == gbT) && b == gbT: // GB8
+		return false
+	case b == gbExtend || b == gbZWJ: // GB9
+		return false
+	case b == gbSpacingMark: // GB9a
+		return false
+	case a == gbPrepend: // GB9b
+		return false
+	case a == gbZWJ && b == gbPictographic && pict: // GB11
+		return false
+	case a == gbRegionalIndicator && b == gbRegionalIndicator: // GB12, GB13
+		return ri%2 == 0
+	default: // GB999
+		return true
+	}
+}
+
+func graphemeBreak(r rune) gbClass {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r == 0x200d:
+		return gbZWJ
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return gbRegionalIndicator
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend) || (r >= 0x1f3fb && r <= 0x1f3ff):
+		return gbExtend
+	case unicode.Is(prepend, r):
+		return gbPrepend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp, unicode.Cs):
+		return gbControl
+	case unicode.Is(unicode.Mc, r) || r == 0x0e33 || r == 0x0eb3:
+		return gbSpacingMark
+	case (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c):
+		return gbL
+	case (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6):
+		return gbV
+	case (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb):
+		return gbT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case unicode.Is(pictographic, r):
+		return gbPictographic
+	default:
+		return gbOther
+	}
+}
+
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0600, 0x0605, 1},
+		{0x06dd, 0x070f, 0x070f - 0x06dd},
+		{0x0890, 0x0891, 1},
+		{0x08e2, 0x0d4e, 0x0d4e - 0x08e2},
+	},
+	R32: []unicode.Range32{
+		{0x110bd, 0x110cd, 0x10},
+		{0x111c2, 0x111c3, 1},
+		{0x1193f, 0x11941, 2},
+		{0x11a3a, 0x11a84, 0x11a84 - 0x11a3a},
+		{0x11a85, 0x11a89, 1},
+		{0x11d46, 0x11d46, 1},
+	},
+}
+
+var pictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00a9, 0x00ae, 5},
+		{0x203c, 0x2049, 0x2049 - 0x203c},
+		{0x2122, 0x2139, 0x2139 - 0x2122},
+		{0x2194, 0x2199, 1},
+		{0x21a9, 0x21aa, 1},
+		{0x231a, 0x231b, 1},
+		{0x2328, 0x2388, 0x2388 - 0x2328},
+		{0x23cf, 0x23e9, 0x23e9 - 0x23cf},
+		{0x23ea, 0x23f3, 1},
+		{0x23f8, 0x23fa, 1},
+		{0x24c2, 0x25aa, 0x25aa - 0x24c2},
+		{0x25ab, 0x25b6, 0x25b6 - 0x25ab},
+		{0x25c0, 0x25fb, 0x25fb - 0x25c0},
+		{0x25fc, 0x25fe, 1},
+		{0x2600, 0x2605, 1},
+		{0x2607, 0x2612, 1},
+		{0x2614, 0x2685, 1},
+		{0x2690, 0x2705, 1},
+		{0x2708, 0x2712, 1},
+		{0x2714, 0x2716, 2},
+		{0x271d, 0x2721, 4},
+		{0x2728, 0x2733, 0x2733 - 0x2728},
+		{0x2734, 0x2744, 0x2744 - 0x2734},
+		{0x2747, 0x274c, 0x274c - 0x2747},
+		{0x274e, 0x2753, 0x2753 - 0x274e},
+		{0x2754, 0x2755, 1},
+		{0x2757, 0x2763, 0x2763 - 0x2757},
+		{0x2764, 0x2767, 1},
+		{0x2795, 0x2797, 1},
+		{0x27a1, 0x27b0, 0x27b0 - 0x27a1},
+		{0x27bf, 0x2934, 0x2934 - 0x27bf},
+		{0x2935, 0x2b05, 0x2b05 - 0x2935},
+		{0x2b06, 0x2b07, 1},
+		{0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b55, 5},
+		{0x3030, 0x303d, 0x303d - 0x3030},
+		{0x3297, 0x3299, 2},
+	},
+	R32: []unicode.Range32{
+		{0x1f000, 0x1f0ff, 1},
+		{0x1f10d, 0x1f10f, 1},
+		{0x1f12f, 0x1f16c, 0x1f16c - 0x1f12f},
+		{0x1f16d, 0x1f171, 1},
+		{0x1f17e, 0x1f17f, 1},
+		{0x1f18e, 0x1f191, 0x1f191 - 0x1f18e},
+		{0x1f192, 0x1f19a, 1},
+		{0x1f1ad, 0x1f1e5, 1},
+		{0x1f201, 0x1f20f, 1},
+		{0x1f21a, 0x1f22f, 0x1f22f - 0x1f21a},
+		{0x1f232, 0x1f23a, 1},
+		{0x1f23c, 0x1f23f, 1},
+		{0x1f249, 0x1f3fa, 1},
+		{0x1f400, 0x1f53d, 1},
+		{0x1f546, 0x1f64f, 1},
+		{0x1f680, 0x1f6ff, 1},
+		{0x1f774, 0x1f77f, 1},
+		{0x1f7d5, 0x1f7ff, 1},
+		{0x1f80c, 0x1f80f, 1},
+		{0x1f848, 0x1f84f, 1},
+		{0x1f85a, 0x1f85f, 1},
+		{0x1f888, 0x1f88f, 1},
+		{0x1f8ae, 0x1f8ff, 1},
+		{0x1f90c, 0x1f93a, 1},
+		{0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1faff, 1},
+		{0x1fc00, 0x1fffd, 1},
+	},
+}
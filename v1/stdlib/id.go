@@ -0,0 +1,609 @@
+package stdlib
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ID validates identifiers and encodings: UUIDs, ULIDs, KSUIDs, semantic
+// versions, slugs, base64, base32 and hex, JWTs, MongoDB ObjectIDs and
+// ISBN and EAN numbers. It is available to expressions as id.
+//
+// Identifiers must be written in their canonical form; surrounding space,
+// prefixes like urn:uuid: or v1.2.3 and line breaks are not permitted.
+type ID struct{}
+
+// UUID reports whether s is a UUID in the 8-4-4-4-12 hexadecimal form, in
+// either case. If any versions are provided, as in id.UUID(self, 4, 7), it
+// reports whether s is a UUID of the RFC 9562 variant with one of those
+// versions, which the nil and max UUIDs are not. A version which is not an
+// integer from 1 to 8 is a configuration error.
+func (v ID) UUID(s string, versions ...interface{}) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return true
+	}
+	ver := unhex(s[14])
+	variant := unhex(s[19])
+	found := false
+	for _, e := range versions {
+		r, ok := number(e)
+		if !ok || !r.IsInt() || r.Num().Int64() < 1 || r.Num().Int64() > 8 {
+			panic(fmt.Errorf("validate: Invalid UUID version: %v", e))
+		}
+		if r.Num().Int64() == int64(ver) {
+			found = true
+		}
+	}
+	return found && variant&0xc == 0x8
+}
+
+// ULID reports whether s is a ULID: 26 characters of Crockford's base32,
+// in either case, the first of which is at most 7 so that the value fits
+// in 128 bits.
+func (v ID) ULID(s string) bool {
+	if len(s) != 26 || s[0] < '0' || s[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(crockford, upper(s[i])) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// KSUID reports whether s is a KSUID: 27 base62 characters which encode
+// a value that fits in 160 bits.
+func (v ID) KSUID(s string) bool {
+	if len(s) != 27 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) {
+			return false
+		}
+	}
+	return s <= maxKSUID // the base62 alphabet is in ASCII order
+}
+
+// ObjectID reports whether s is a MongoDB ObjectID: 24 hexadecimal digits,
+// in either case.
+func (v ID) ObjectID(s string) bool {
+	return len(s) == 24 && v.Hex(s)
+}
+
+// Semver reports whether s is a semantic version as described by Semantic
+// Versioning 2.0.0, like 1.2.3-rc.1+build.5
+func (v ID) Semver(s string) bool {
+	_, ok := parseSemver(s)
+	return ok
+}
+
+// Satisfies reports whether s is a semantic version which satisfies the
+// range r, as in id.Satisfies(self, ">=1.2.0 <2.0.0 || ^3.1"). Ranges are
+// written as npm describes them: sets of comparators separated by ||, each
+// of which is satisfied when all of its comparators are. A comparator is a
+// version with an operator (<, <=, >, >=, =, ~ or ^), a partial version
+// with wildcards, like 1.2.x, or a hyphen range, like 1.2 - 2. As with
+// npm, a pre-release version only satisfies a set in which a comparator
+// has a pre-release of the same major, minor and patch version. A range
+// which cannot be parsed is a configuration error.
+func (v ID) Satisfies(s, r string) bool {
+	sets, err := parseRange(r)
+	if err != nil {
+		panic(fmt.Errorf("validate: Invalid version range: %s: %v", r, err))
+	}
+	ver, ok := parseSemver(s)
+	if !ok {
+		return false
+	}
+	for _, e := range sets {
+		if e.test(ver) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slug reports whether s is a URL slug: lowercase letters and digits in
+// words separated by single hyphens, like my-first-post
+func (v ID) Slug(s string) bool {
+	return slug.MatchString(s)
+}
+
+// Hex reports whether s is an even number of hexadecimal digits, in
+// either case, which encode bytes
+func (v ID) Hex(s string) bool {
+	if len(s)%2 != 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Base64 reports whether s is padded standard base64, as described by RFC
+// 4648. The empty string encodes no bytes, so it is valid.
+func (v ID) Base64(s string) bool {
+	return canonical(s, base64.StdEncoding)
+}
+
+// Base64URL reports whether s is URL-safe base64, as described by RFC 4648,
+// with or without padding. The empty string encodes no bytes, so it is
+// valid.
+func (v ID) Base64URL(s string) bool {
+	return canonical(s, base64.RawURLEncoding) || canonical(s, base64.URLEncoding)
+}
+
+// Base32 reports whether s is padded standard base32, in upper case, as
+// described by RFC 4648. The empty string encodes no bytes, so it is valid.
+func (v ID) Base32(s string) bool {
+	return canonical(s, base32.StdEncoding)
+}
+
+// JWT reports whether s has the structure of a JSON Web Token in compact
+// serialization: a header, payload and signature in unpadded base64url,
+// separated by dots, where the header and payload are JSON objects and the
+// header names an algorithm. The signature is empty if and only if the
+// algorithm is none. The signature itself is not verified.
+func (v ID) JWT(s string) bool {
+	p := strings.Split(s, ".")
+	if len(p) != 3 || !canonical(p[2], base64.RawURLEncoding) {
+		return false
+	}
+	var header struct {
+		Alg *string `json:"alg"`
+	}
+	if !jsonObject(p[0], &header) || !jsonObject(p[1], nil) {
+		return false
+	}
+	if header.Alg == nil || *header.Alg == "" {
+		return false
+	}
+	return (*header.Alg == "none") == (p[2] == "")
+}
+
+// ISBN reports whether s is an ISBN-10 or ISBN-13 with a valid check
+// digit. Groups may be separated by single hyphens or spaces, as in
+// 978-3-16-148410-0, and the check digit of an ISBN-10 may be X.
+func (v ID) ISBN(s string) bool {
+	d, ok := separated(s, true)
+	if !ok {
+		return false
+	}
+	switch len(d) {
+	case 10:
+		return isbn10(d)
+	case 13:
+		return (strings.HasPrefix(d, "978") || strings.HasPrefix(d, "979")) && gtin(d)
+	default:
+		return false
+	}
+}
+
+// EAN reports whether s is an EAN-8 or EAN-13 number, without separators,
+// with a valid check digit
+func (v ID) EAN(s string) bool {
+	if len(s) != 8 && len(s) != 13 {
+		return false
+	}
+	d, ok := separated(s, false)
+	return ok && gtin(d)
+}
+
+const (
+	crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	maxKSUID  = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+var slug = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	default:
+		return c - '0'
+	}
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+type encoding interface {
+	DecodeString(string) ([]byte, error)
+	EncodeToString([]byte) string
+}
+
+// canonical reports whether s is exactly the encoding of the bytes it
+// decodes to, which rejects line breaks and non-zero trailing bits that
+// decoders otherwise tolerate
+func canonical(s string, enc encoding) bool {
+	b, err := enc.DecodeString(s)
+	return err == nil && enc.EncodeToString(b) == s
+}
+
+// jsonObject reports whether s is the unpadded base64url encoding of a JSON
+// object, which is decoded into x if it is not nil
+func jsonObject(s string, x interface{}) bool {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || base64.RawURLEncoding.EncodeToString(b) != s {
+		return false
+	}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' || !json.Valid(b) {
+		return false
+	}
+	return x == nil || json.Unmarshal(b, x) == nil
+}
+
+// separated removes the separators from s, which must be digits separated,
+// if sep is set, by single hyphens or spaces. An X is permitted as the last
+// character when separators are.
+func separated(s string, sep bool) (string, bool) {
+	var d strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			d.WriteByte(c)
+		case sep && (c == 'X' || c == 'x') && i == len(s)-1:
+			d.WriteByte('X')
+		case sep && (c == '-' || c == ' ') && i > 0 && i < len(s)-1 && s[i-1] != '-' && s[i-1] != ' ':
+		default:
+			return "", false
+		}
+	}
+	return d.String(), true
+}
+
+func isbn10(d string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		n := int(d[i] - '0')
+		if d[i] == 'X' {
+			if i != 9 {
+				return false
+			}
+			n = 10
+		}
+		sum += (10 - i) * n
+	}
+	return sum%11 == 0
+}
+
+// gtin reports whether the last of the digits d is the GS1 check digit of
+// the others, as in EAN-8, EAN-13 and ISBN-13
+func gtin(d string) bool {
+	sum := 0
+	for i := 0; i < len(d); i++ {
+		if d[i] < '0' || d[i] > '9' {
+			return false
+		}
+		n := int(d[i] - '0')
+		if (len(d)-i)%2 == 0 {
+			n *= 3
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// semver is a semantic version; build metadata is discarded, since it
+// does not affect precedence
+type semver struct {
+	num [3]uint64
+	pre []string
+}
+
+var (
+	semverPattern  = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	partialPattern = regexp.MustCompile(`^v?(0|[1-9]\d*|[xX*])(?:\.(0|[1-9]\d*|[xX*]))?(?:\.(0|[1-9]\d*|[xX*]))?(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	operatorSpace  = regexp.MustCompile(`(<=|>=|<|>|=|~|\^)\s+`)
+)
+
+func parseSemver(s string) (semver, bool) {
+	m := semverPattern.FindStringSubmatch(s)
+	if m == nil {
+		return semver{}, false
+	}
+	var v semver
+	for i := range v.num {
+		n, err := strconv.ParseUint(m[i+1], 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		v.num[i] = n
+	}
+	if m[4] != "" {
+		v.pre = strings.Split(m[4], ".")
+	}
+	return v, true
+}
+
+func (v semver) compare(w semver) int {
+	for i := range v.num {
+		if v.num[i] != w.num[i] {
+			return cmpUint(v.num[i], w.num[i])
+		}
+	}
+	switch {
+	case len(v.pre) == 0 && len(w.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(w.pre); i++ {
+		if n := comparePre(v.pre[i], w.pre[i]); n != 0 {
+			return n
+		}
+	}
+	return cmpUint(uint64(len(v.pre)), uint64(len(w.pre)))
+}
+
+// comparePre orders pre-release identifiers: numeric identifiers compare
+// numerically and are lower than alphanumeric ones, which compare lexically
+func comparePre(a, b string) int {
+	an, bn := numeric(a), numeric(b)
+	switch {
+	case an && bn:
+		if len(a) != len(b) { // numeric identifiers have no leading zeros
+			return cmpUint(uint64(len(a)), uint64(len(b)))
+		}
+		return strings.Compare(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func cmpUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// partial is a version in a range, some of whose components may be
+// wildcards or omitted; n is the number of components which are provided
+type partial struct {
+	semver
+	n int
+}
+
+func parsePartial(s string) (partial, error) {
+	m := partialPattern.FindStringSubmatch(s)
+	if m == nil {
+		return partial{}, fmt.Errorf("Invalid version: %s", s)
+	}
+	var p partial
+	for i := 0; i < 3; i++ {
+		c := m[i+1]
+		if c == "" || c == "x" || c == "X" || c == "*" {
+			break
+		}
+		n, err := strconv.ParseUint(c, 10, 64)
+		if err != nil {
+			return partial{}, fmt.Errorf("Invalid version: %s", s)
+		}
+		p.num[i] = n
+		p.n++
+	}
+	for i := p.n + 1; i <= 3; i++ {
+		if c := m[i]; c != "" && c != "x" && c != "X" && c != "*" {
+			return partial{}, fmt.Errorf("Invalid version: %s", s)
+		}
+	}
+	if m[4] != "" {
+		if p.n < 3 {
+			return partial{}, fmt.Errorf("Invalid version: %s", s)
+		}
+		p.pre = strings.Split(m[4], ".")
+	}
+	return p, nil
+}
+
+// bump is the lowest version whose first n components differ from those
+// of p, which excludes every version matched by p's first n components; it
+// has the lowest possible pre-release when the bound is exclusive
+func (p partial) bump(n int, exclusive bool) semver {
+	v := semver{num: p.num}
+	v.num[n-1]++
+	for i := n; i < 3; i++ {
+		v.num[i] = 0
+	}
+	if exclusive {
+		v.pre = []string{"0"}
+	}
+	return v
+}
+
+type comparator struct {
+	op string
+	v  semver
+}
+
+func (c comparator) test(v semver) bool {
+	n := v.compare(c.v)
+	switch c.op {
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	default:
+		return n == 0
+	}
+}
+
+// none matches no version at all
+var none = comparator{"<", semver{pre: []string{"0"}}}
+
+// comparators is a set of comparators, all of which must be satisfied
+type comparators []comparator
+
+func (c comparators) test(v semver) bool {
+	for _, e := range c {
+		if !e.test(v) {
+			return false
+		}
+	}
+	if len(v.pre) == 0 {
+		return true
+	}
+	for _, e := range c {
+		if len(e.v.pre) > 0 && e.v.num == v.num {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRange(r string) ([]comparators, error) {
+	var sets []comparators
+	for _, e := range strings.Split(r, "||") {
+		c, err := parseComparators(operatorSpace.ReplaceAllString(strings.TrimSpace(e), "$1"))
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, c)
+	}
+	return sets, nil
+}
+
+func parseComparators(s string) (comparators, error) {
+	f := strings.Fields(s)
+	if len(f) == 3 && f[1] == "-" {
+		return hyphenRange(f[0], f[2])
+	}
+	var set comparators
+	for _, e := range f {
+		c, err := parseComparator(e)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, c...)
+	}
+	return set, nil
+}
+
+func hyphenRange(from, to string) (comparators, error) {
+	a, err := parsePartial(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parsePartial(to)
+	if err != nil {
+		return nil, err
+	}
+	var set comparators
+	if a.n > 0 {
+		set = append(set, comparator{">=", a.semver})
+	}
+	switch {
+	case b.n == 3:
+		set = append(set, comparator{"<=", b.semver})
+	case b.n > 0:
+		set = append(set, comparator{"<", b.bump(b.n, true)})
+	}
+	return set, nil
+}
+
+func parseComparator(s string) (comparators, error) {
+	op := s[:len(s)-len(strings.TrimLeft(s, "<>=~^"))]
+	p, err := parsePartial(s[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	if p.n == 0 { // every version matches the wildcard
+		switch op {
+		case "<", ">":
+			return comparators{none}, nil
+		default:
+			return nil, nil
+		}
+	}
+	switch op {
+	case "", "=":
+		if p.n == 3 {
+			return comparators{{"=", p.semver}}, nil
+		}
+		return comparators{{">=", p.semver}, {"<", p.bump(p.n, true)}}, nil
+	case ">":
+		if p.n == 3 {
+			return comparators{{">", p.semver}}, nil
+		}
+		return comparators{{">=", p.bump(p.n, false)}}, nil
+	case ">=":
+		return comparators{{">=", p.semver}}, nil
+	case "<":
+		if p.n == 3 {
+			return comparators{{"<", p.semver}}, nil
+		}
+		return comparators{{"<", semver{num: p.num, pre: []string{"0"}}}}, nil
+	case "<=":
+		if p.n == 3 {
+			return comparators{{"<=", p.semver}}, nil
+		}
+		return comparators{{"<", p.bump(p.n, true)}}, nil
+	case "~":
+		return comparators{{">=", p.semver}, {"<", p.bump(min(p.n, 2), true)}}, nil
+	case "^":
+		n := p.n // the first non-zero component which is provided may not change
+		for i := 0; i < p.n; i++ {
+			if p.num[i] != 0 {
+				n = i + 1
+				break
+			}
+		}
+		return comparators{{">=", p.semver}, {"<", p.bump(n, true)}}, nil
+	default:
+		return nil, fmt.Errorf("Invalid operator: %s", op)
+	}
+}
@@ -0,0 +1,99 @@
+package stdlib
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ISO validates codes from ISO reference data: countries, subdivisions of
+// countries and currencies. It is available to expressions as iso.
+//
+// Codes must be in upper case. The tables are those of iso-codes 4.15.0,
+// so codes which have been assigned or withdrawn since then are not
+// reflected; the minor units of currencies are those of the ISO 4217 list
+// published by SIX.
+type ISO struct{}
+
+// Country reports whether s is an ISO 3166-1 alpha-2 country code, like US
+func (v ISO) Country(s string) bool {
+	_, ok := countries[s]
+	return ok
+}
+
+// Alpha3 reports whether s is an ISO 3166-1 alpha-3 country code, like USA
+func (v ISO) Alpha3(s string) bool {
+	_, ok := alpha3[s]
+	return ok
+}
+
+// Subdivision reports whether s is an ISO 3166-2 code for a subdivision of
+// a country, like US-CA
+func (v ISO) Subdivision(s string) bool {
+	_, ok := subdivisions[s]
+	return ok
+}
+
+// SubdivisionOf reports whether s is an ISO 3166-2 code for a subdivision
+// of the country whose alpha-2 code is country, as in
+// iso.SubdivisionOf(self, sup.Country)
+func (v ISO) SubdivisionOf(s, country string) bool {
+	return v.Subdivision(s) && strings.HasPrefix(s, country+"-")
+}
+
+// Currency reports whether s is an ISO 4217 currency code, like USD
+func (v ISO) Currency(s string) bool {
+	_, ok := currencies[s]
+	return ok
+}
+
+var (
+	//go:embed data/iso3166-1.txt
+	iso3166_1 string
+	//go:embed data/iso3166-2.txt
+	iso3166_2 string
+	//go:embed data/iso4217.txt
+	iso4217 string
+)
+
+var (
+	countries    = make(map[string]struct{})
+	alpha3       = make(map[string]struct{})
+	subdivisions = make(map[string]struct{})
+	currencies   = make(map[string]int) // the number of digits of minor units, or -1 if there are none
+)
+
+func init() {
+	table(iso3166_1, 2, func(f []string) {
+		countries[f[0]], alpha3[f[1]] = struct{}{}, struct{}{}
+	})
+	table(iso3166_2, 1, func(f []string) {
+		subdivisions[f[0]] = struct{}{}
+	})
+	table(iso4217, 2, func(f []string) {
+		n := -1
+		if f[1] != "-" {
+			var err error
+			if n, err = strconv.Atoi(f[1]); err != nil {
+				panic(fmt.Errorf("validate: Invalid minor units: %v", f))
+			}
+		}
+		currencies[f[0]] = n
+	})
+}
+
+// table invokes f with the fields of every line of an embedded table which
+// is not blank or a comment; each line must have n fields
+func table(data string, n int, f func([]string)) {
+	for _, l := range strings.Split(data, "\n") {
+		if l = strings.TrimSpace(l); l == "" || l[0] == '#' {
+			continue
+		}
+		e := strings.Fields(l)
+		if len(e) != n {
+			panic(fmt.Errorf("validate: Invalid table entry: %s", l))
+		}
+		f(e)
+	}
+}
@@ -0,0 +1,52 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISOCodes(t *testing.T) {
+	v := ISO{}
+	assert.True(t, v.Country("US"))
+	assert.True(t, v.Country("GB"))
+	assert.False(t, v.Country("UK"))
+	assert.False(t, v.Country("us"))
+	assert.False(t, v.Country("USA"))
+	assert.False(t, v.Country(""))
+
+	assert.True(t, v.Alpha3("USA"))
+	assert.True(t, v.Alpha3("GBR"))
+	assert.False(t, v.Alpha3("US"))
+	assert.False(t, v.Alpha3("UKK"))
+
+	assert.True(t, v.Subdivision("US-CA"))
+	assert.True(t, v.Subdivision("GB-ENG"))
+	assert.True(t, v.Subdivision("FR-75"))
+	assert.False(t, v.Subdivision("US-XX"))
+	assert.False(t, v.Subdivision("US"))
+	assert.False(t, v.Subdivision("us-ca"))
+	assert.True(t, v.SubdivisionOf("US-CA", "US"))
+	assert.False(t, v.SubdivisionOf("US-CA", "CA"))
+	assert.False(t, v.SubdivisionOf("US-CA", ""))
+
+	assert.True(t, v.Currency("USD"))
+	assert.True(t, v.Currency("EUR"))
+	assert.True(t, v.Currency("XAU"))
+	assert.False(t, v.Currency("usd"))
+	assert.False(t, v.Currency("ZZZ"))
+	assert.False(t, v.Currency("US"))
+}
+
+func TestISOTables(t *testing.T) {
+	assert.Len(t, countries, 249)
+	assert.Len(t, alpha3, 249)
+	assert.Len(t, currencies, 181)
+	assert.Equal(t, 0, currencies["JPY"])
+	assert.Equal(t, 2, currencies["USD"])
+	assert.Equal(t, 3, currencies["BHD"])
+	assert.Equal(t, -1, currencies["XAU"])
+	for k := range ibanLengths {
+		assert.True(t, k == "XK" || ISO{}.Country(k), "IBAN country: %s", k)
+	}
+}
@@ -0,0 +1,359 @@
+package stdlib
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Net validates network addresses, hostnames, email addresses and URLs.
+// It is available to expressions as net.
+type Net struct{}
+
+// IP reports whether s is an IPv4 or IPv6 address
+func (v Net) IP(s string) bool {
+	_, ok := parseAddr(s)
+	return ok
+}
+
+// IPv4 reports whether s is an IPv4 address
+func (v Net) IPv4(s string) bool {
+	a, ok := parseAddr(s)
+	return ok && a.Is4()
+}
+
+// IPv6 reports whether s is an IPv6 address, including an IPv4 address in
+// IPv6 notation, like ::ffff:10.0.0.1
+func (v Net) IPv6(s string) bool {
+	a, ok := parseAddr(s)
+	return ok && a.Is6()
+}
+
+// CIDR reports whether s is an IP prefix in CIDR notation, like 10.0.0.0/8,
+// in which no bits are set beyond the prefix.
+func (v Net) CIDR(s string) bool {
+	p, err := netip.ParsePrefix(s)
+	return err == nil && p == p.Masked()
+}
+
+// InCIDR reports whether s is an IP address within the prefix described by
+// cidr. An invalid prefix is a configuration error.
+func (v Net) InCIDR(s, cidr string) bool {
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		panic(fmt.Errorf("validate: Invalid CIDR: %s", cidr))
+	}
+	a, ok := parseAddr(s)
+	if !ok {
+		return false
+	}
+	if p.Addr().Is4() {
+		a = a.Unmap()
+	}
+	return p.Contains(a)
+}
+
+// PublicIP reports whether s is an IP address which is publicly routable:
+// not a loopback, link-local, private, multicast or otherwise reserved
+// address, including an IPv4 address of any of those kinds which is
+// embedded in an IPv6 address.
+func (v Net) PublicIP(s string) bool {
+	a, ok := parseAddr(s)
+	return ok && publicAddr(a)
+}
+
+// Hostname reports whether s is a hostname as described by RFC 1123: labels
+// of letters, digits and hyphens separated by dots. Internationalized names
+// are permitted and are validated in their punycode form.
+func (v Net) Hostname(s string) bool {
+	_, ok := hostname(s)
+	return ok
+}
+
+// FQDN reports whether s is a fully qualified domain name: a hostname with
+// at least two labels, the last of which is not numeric. A trailing dot is
+// permitted.
+func (v Net) FQDN(s string) bool {
+	l, ok := hostname(s)
+	return ok && len(l) > 1 && !numeric(l[len(l)-1])
+}
+
+// Port reports whether p, a number or a string of digits, is a port number
+// between 1 and 65535.
+func (v Net) Port(p interface{}) bool {
+	z := reflect.ValueOf(p)
+	switch z.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return z.Int() > 0 && z.Int() <= 65535
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return z.Uint() > 0 && z.Uint() <= 65535
+	case reflect.Float32, reflect.Float64:
+		f := z.Float()
+		return f == float64(int64(f)) && f > 0 && f <= 65535
+	case reflect.String:
+		return port(z.String())
+	default:
+		return false
+	}
+}
+
+// Email reports whether s is an email address as described by the
+// addr-spec production of RFC 5322, like user@example.com. Display names,
+// comments and obsolete forms are not permitted.
+func (v Net) Email(s string) bool {
+	var rest string
+	var ok bool
+	if strings.HasPrefix(s, `"`) {
+		rest, ok = quotedString(s)
+	} else {
+		x := strings.IndexByte(s, '@')
+		if x < 0 {
+			return false
+		}
+		rest, ok = s[x:], dotAtom(s[:x])
+	}
+	if !ok || !strings.HasPrefix(rest, "@") {
+		return false
+	}
+	domain := rest[1:]
+	return dotAtom(domain) || domainLiteral(domain)
+}
+
+// URL reports whether s is an absolute URL with a valid host and, if any
+// schemes are provided, whether its scheme is one of them.
+func (v Net) URL(s string, schemes ...interface{}) bool {
+	_, ok := parseURL(s, schemes)
+	return ok
+}
+
+// PublicURL reports whether s is a URL, as with URL, which does not refer
+// to a loopback, link-local, private or otherwise reserved address, so a
+// server may make requests to it on behalf of a client. Hostnames are not
+// resolved, so a name which resolves to a private address must still be
+// rejected when a connection is made.
+func (v Net) PublicURL(s string, schemes ...interface{}) bool {
+	u, ok := parseURL(s, schemes)
+	if !ok {
+		return false
+	}
+	host := u.Hostname()
+	if a, ok := parseAddr(host); ok {
+		return publicAddr(a)
+	}
+	l, _ := hostname(host)
+	last := l[len(l)-1]
+	// names beneath localhost are loopback addresses and a numeric last
+	// label is interpreted as an address by many resolvers, as in 127.1
+	return last != "localhost" && !numeric(last) && !strings.HasPrefix(last, "0x")
+}
+
+func parseAddr(s string) (netip.Addr, bool) {
+	a, err := netip.ParseAddr(s)
+	if err != nil || a.Zone() != "" {
+		return netip.Addr{}, false
+	}
+	return a, true
+}
+
+var (
+	reserved = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),       // this network
+		netip.MustParsePrefix("100.64.0.0/10"),   // shared address space
+		netip.MustParsePrefix("192.0.0.0/24"),    // protocol assignments
+		netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+		netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+		netip.MustParsePrefix("198.51.100.0/24"), // documentation
+		netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+		netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+		netip.MustParsePrefix("100::/64"),        // discard
+		netip.MustParsePrefix("2001:db8::/32"),   // documentation
+		netip.MustParsePrefix("64:ff9b:1::/48"),  // local translation
+		netip.MustParsePrefix("fec0::/10"),       // site-local
+	}
+	nat64      = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour  = netip.MustParsePrefix("2002::/16")
+	compatible = netip.MustParsePrefix("::/96") // deprecated IPv4-compatible addresses, other than :: and ::1
+)
+
+func publicAddr(a netip.Addr) bool {
+	a = a.Unmap()
+	if a.IsLoopback() || a.IsPrivate() || a.IsUnspecified() || a.IsMulticast() || a.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, e := range reserved {
+		if e.Contains(a) {
+			return false
+		}
+	}
+	b := a.As16()
+	switch {
+	case nat64.Contains(a), compatible.Contains(a):
+		return publicAddr(netip.AddrFrom4([4]byte(b[12:16])))
+	case sixToFour.Contains(a):
+		return publicAddr(netip.AddrFrom4([4]byte(b[2:6])))
+	default:
+		return true
+	}
+}
+
+// hostname converts a hostname to its ASCII form and returns its labels,
+// excluding the empty label after a trailing dot.
+func hostname(s string) ([]string, bool) {
+	a, err := idna.Lookup.ToASCII(s)
+	if err != nil || (isASCII(s) && !strings.EqualFold(a, s)) { // punycode must be canonical
+		return nil, false
+	}
+	a = strings.TrimSuffix(a, ".")
+	if a == "" || len(a) > 253 {
+		return nil, false
+	}
+	l := strings.Split(a, ".")
+	for _, e := range l {
+		if !label(e) {
+			return nil, false
+		}
+	}
+	return l, true
+}
+
+func label(s string) bool {
+	if len(s) < 1 || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isAlnum(c) && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func numeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+func port(s string) bool {
+	if !numeric(s) || len(s) > 5 {
+		return false
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0 && n <= 65535
+}
+
+func parseURL(s string, schemes []interface{}) (*url.URL, bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Opaque != "" || u.Host == "" {
+		return nil, false
+	}
+	if len(schemes) > 0 {
+		var ok bool
+		for _, e := range schemes {
+			n, isString := e.(string)
+			if !isString {
+				panic(fmt.Errorf("validate: Scheme is not a string: %v", e))
+			}
+			if strings.EqualFold(n, u.Scheme) {
+				ok = true
+			}
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	if p := u.Port(); p != "" && !port(p) {
+		return nil, false
+	}
+	host := u.Hostname()
+	if _, ok := parseAddr(host); ok {
+		return u, true
+	}
+	if strings.HasPrefix(u.Host, "[") {
+		return nil, false // a bracketed host must be an IPv6 address
+	}
+	if _, ok := hostname(host); !ok {
+		return nil, false
+	}
+	return u, true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isAtext reports whether c is an atext character, as described by RFC 5322
+func isAtext(c byte) bool {
+	return isAlnum(c) || strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+// dotAtom reports whether s is a dot-atom, as described by RFC 5322
+func dotAtom(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c != '.' && !isAtext(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// quotedString consumes a quoted-string, as described by RFC 5322, from the
+// start of s and returns the remainder.
+func quotedString(s string) (string, bool) {
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			return s[i+1:], true
+		case c == '\\':
+			if i+1 >= len(s) || !(isVchar(s[i+1]) || isWsp(s[i+1])) {
+				return "", false
+			}
+			i++
+		case !isVchar(c) && !isWsp(c):
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// domainLiteral reports whether s is a domain-literal, as described by
+// RFC 5322, like [192.0.2.1]
+func domainLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return false
+	}
+	for i := 1; i < len(s)-1; i++ {
+		if c := s[i]; c == '[' || c == ']' || c == '\\' || !(isVchar(c) || isWsp(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVchar(c byte) bool {
+	return c >= 0x21 && c <= 0x7e
+}
+
+func isWsp(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,177 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetAddrs(t *testing.T) {
+	n := Net{}
+	tests := []struct {
+		In                           string
+		IP, IPv4, IPv6, CIDR, Public bool
+	}{
+		{"", false, false, false, false, false},
+		{"10.0.0.1", true, true, false, false, false},
+		{"8.8.8.8", true, true, false, false, true},
+		{"::1", true, false, true, false, false},
+		{"2606:4700::1111", true, false, true, false, true},
+		{"::ffff:127.0.0.1", true, false, true, false, false},
+		{"::ffff:8.8.8.8", true, false, true, false, true},
+		{"64:ff9b::a00:1", true, false, true, false, false},
+		{"2002:c0a8:101::1", true, false, true, false, false},
+		{"::127.0.0.1", true, false, true, false, false},
+		{"::10.0.0.1", true, false, true, false, false},
+		{"::8.8.8.8", true, false, true, false, true},
+		{"fe80::1%eth0", false, false, false, false, false},
+		{"fd00::1", true, false, true, false, false},
+		{"169.254.169.254", true, true, false, false, false},
+		{"100.64.0.1", true, true, false, false, false},
+		{"0.0.0.0", true, true, false, false, false},
+		{"255.255.255.255", true, true, false, false, false},
+		{"224.0.0.1", true, true, false, false, false},
+		{"010.0.0.1", false, false, false, false, false},
+		{"10.0.0.0/8", false, false, false, true, false},
+		{"10.0.0.1/8", false, false, false, false, false},
+		{"2001:db8::/32", false, false, false, true, false},
+		{"10.0.0.0/33", false, false, false, false, false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.IP, n.IP(e.In), "IP: %s", e.In)
+		assert.Equal(t, e.IPv4, n.IPv4(e.In), "IPv4: %s", e.In)
+		assert.Equal(t, e.IPv6, n.IPv6(e.In), "IPv6: %s", e.In)
+		assert.Equal(t, e.CIDR, n.CIDR(e.In), "CIDR: %s", e.In)
+		assert.Equal(t, e.Public, n.PublicIP(e.In), "PublicIP: %s", e.In)
+	}
+
+	assert.True(t, n.InCIDR("10.1.2.3", "10.0.0.0/8"))
+	assert.True(t, n.InCIDR("::ffff:10.1.2.3", "10.0.0.0/8"))
+	assert.False(t, n.InCIDR("11.1.2.3", "10.0.0.0/8"))
+	assert.False(t, n.InCIDR("nope", "10.0.0.0/8"))
+	assert.True(t, n.InCIDR("2001:db8::1", "2001:db8::/32"))
+	assert.Panics(t, func() { n.InCIDR("10.1.2.3", "10.0.0.0") })
+}
+
+func TestNetNames(t *testing.T) {
+	n := Net{}
+	tests := []struct {
+		In             string
+		Hostname, FQDN bool
+	}{
+		{"", false, false},
+		{"localhost", true, false},
+		{"example.com", true, true},
+		{"example.com.", true, true},
+		{"Example.COM", true, true},
+		{"a-b.example.com", true, true},
+		{"-ab.example.com", false, false},
+		{"ab-.example.com", false, false},
+		{"a..example.com", false, false},
+		{"under_score.example.com", false, false},
+		{"bücher.example", true, true},
+		{"xn--bcher-kva.example", true, true},
+		{"xn--a.example", false, false},
+		{"xn--abc-.example", false, false},
+		{"xn--.example", false, false},
+		{"127.0.0.1", true, false},
+		{"a.b.c.d.e.123", true, false},
+		{"example.com:80", false, false},
+		{string(make([]byte, 64)), false, false},
+		{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.com", false, false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Hostname, n.Hostname(e.In), "Hostname: %s", e.In)
+		assert.Equal(t, e.FQDN, n.FQDN(e.In), "FQDN: %s", e.In)
+	}
+}
+
+func TestNetPort(t *testing.T) {
+	n := Net{}
+	for _, e := range []interface{}{1, int64(443), uint16(8080), 65535.0, "80", "65535"} {
+		assert.True(t, n.Port(e), "%v", e)
+	}
+	for _, e := range []interface{}{0, -1, 65536, 80.5, "", "0", "+80", "65536", "123456", "http", nil, true} {
+		assert.False(t, n.Port(e), "%v", e)
+	}
+}
+
+func TestNetEmail(t *testing.T) {
+	n := Net{}
+	for _, e := range []string{
+		"user@example.com",
+		"first.last+tag@example.com",
+		"!#$%&'*+-/=?^_`{|}~@example.com",
+		`"john doe"@example.com`,
+		`"quoted\"quote"@example.com`,
+		`"a@b"@example.com`,
+		"user@localhost",
+		"user@[192.0.2.1]",
+		"user@[IPv6:2001:db8::1]",
+	} {
+		assert.True(t, n.Email(e), e)
+	}
+	for _, e := range []string{
+		"",
+		"user",
+		"@example.com",
+		"user@",
+		".user@example.com",
+		"user.@example.com",
+		"us..er@example.com",
+		"us er@example.com",
+		"user@exa mple.com",
+		"user@example..com",
+		`"unterminated@example.com`,
+		`"a"b@example.com`,
+		"user@[192.0.2.1",
+		"John Doe <user@example.com>",
+		"user@example.com (comment)",
+		"üser@example.com",
+	} {
+		assert.False(t, n.Email(e), e)
+	}
+}
+
+func TestNetURL(t *testing.T) {
+	n := Net{}
+	tests := []struct {
+		In          string
+		Schemes     []interface{}
+		URL, Public bool
+	}{
+		{"", nil, false, false},
+		{"example.com", nil, false, false},
+		{"/relative/path", nil, false, false},
+		{"mailto:user@example.com", nil, false, false},
+		{"https://example.com/hook?a=b", nil, true, true},
+		{"https://example.com:8443/hook", nil, true, true},
+		{"https://example.com:0/hook", nil, false, false},
+		{"https://example.com:99999/hook", nil, false, false},
+		{"https://bücher.example/", nil, true, true},
+		{"https://exa_mple.com/", nil, false, false},
+		{"https://example.com/", []interface{}{"http", "https"}, true, true},
+		{"HTTPS://example.com/", []interface{}{"https"}, true, true},
+		{"ftp://example.com/", []interface{}{"http", "https"}, false, false},
+		{"http://8.8.8.8/", nil, true, true},
+		{"http://[2606:4700::1111]:443/", nil, true, true},
+		{"http://[example.com]/", nil, false, false},
+		{"http://127.0.0.1/", nil, true, false},
+		{"http://[::1]/", nil, true, false},
+		{"http://[::ffff:127.0.0.1]/", nil, true, false},
+		{"http://[::127.0.0.1]/", nil, true, false},
+		{"http://10.0.0.1/", nil, true, false},
+		{"http://169.254.169.254/latest/meta-data", nil, true, false},
+		{"http://localhost:8080/", nil, true, false},
+		{"http://api.localhost/", nil, true, false},
+		{"http://2130706433/", nil, true, false},
+		{"http://127.1/", nil, true, false},
+		{"http://0x7f000001/", nil, true, false},
+		{"http://good.example@127.0.0.1/", nil, true, false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.URL, n.URL(e.In, e.Schemes...), "URL: %s", e.In)
+		assert.Equal(t, e.Public, n.PublicURL(e.In, e.Schemes...), "PublicURL: %s", e.In)
+	}
+	assert.Panics(t, func() { n.URL("https://example.com/", 443) })
+}
@@ -0,0 +1,247 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Num validates numbers exactly, without rounding. It is available to
+// expressions as num.
+//
+// Numbers may be integers, floats, *big.Int, *big.Float, *big.Rat or
+// decimal strings like "-12.50". Floats are interpreted as the shortest
+// decimal which represents them, so the literal 0.1 is exactly one tenth,
+// while the values of *big.Float are exact.
+type Num struct{}
+
+// Decimal reports whether s is a decimal string: an optional sign, digits
+// and optionally a point followed by more digits, like -12.50
+func (v Num) Decimal(s string) bool {
+	return decimal(s)
+}
+
+// Finite reports whether x is a number which is neither infinite nor NaN
+func (v Num) Finite(x interface{}) bool {
+	_, ok := number(x)
+	return ok
+}
+
+// Integer reports whether x is a number with no fractional part
+func (v Num) Integer(x interface{}) bool {
+	r, ok := number(x)
+	return ok && r.IsInt()
+}
+
+// Compare compares two numbers and returns -1, 0 or 1 as a is less than,
+// equal to or greater than b. Since comparing anything other than numbers
+// causes a panic, validate input first, as in: num.Decimal(self) && num.Compare(self, 0) > 0
+func (v Num) Compare(a, b interface{}) int {
+	return mustNumber(a).Cmp(mustNumber(b))
+}
+
+// Positive reports whether x is a number greater than zero
+func (v Num) Positive(x interface{}) bool {
+	r, ok := number(x)
+	return ok && r.Sign() > 0
+}
+
+// Negative reports whether x is a number less than zero
+func (v Num) Negative(x interface{}) bool {
+	r, ok := number(x)
+	return ok && r.Sign() < 0
+}
+
+// Between reports whether x is a number which is neither less than lo nor
+// greater than hi. Bounds which are not numbers are a configuration error.
+func (v Num) Between(x, lo, hi interface{}) bool {
+	l, h := mustNumber(lo), mustNumber(hi)
+	r, ok := number(x)
+	return ok && r.Cmp(l) >= 0 && r.Cmp(h) <= 0
+}
+
+// MultipleOf reports whether x is a number which is an integer multiple of
+// m, as in num.MultipleOf(self, 0.05). A multiple which is not a number, or
+// is zero, is a configuration error.
+func (v Num) MultipleOf(x, m interface{}) bool {
+	d := mustNumber(m)
+	if d.Sign() == 0 {
+		panic(fmt.Errorf("validate: Cannot be a multiple of zero"))
+	}
+	r, ok := number(x)
+	return ok && new(big.Rat).Quo(r, d).IsInt()
+}
+
+// MaxDecimals reports whether x is a number with at most n digits after
+// the decimal point. Decimal strings are considered as they are written,
+// so "12.50" has two decimals; rationals which have no finite decimal
+// representation, like 1/3, have too many.
+func (v Num) MaxDecimals(x interface{}, n float64) bool {
+	if n < 0 || n != math.Trunc(n) {
+		panic(fmt.Errorf("validate: Invalid number of decimals: %v", n))
+	}
+	d, ok := decimals(x)
+	return ok && float64(d) <= n
+}
+
+// Fits reports whether x is a number which can be converted to the named
+// numeric type, like int32 or uint8, without overflowing or, for integer
+// types, being truncated. An unknown type is a configuration error.
+func (v Num) Fits(x interface{}, kind string) bool {
+	lim, ok := limits[kind]
+	if !ok {
+		panic(fmt.Errorf("validate: Unsupported numeric type: %s", kind))
+	}
+	r, ok := number(x)
+	if !ok || (lim.integer && !r.IsInt()) {
+		return false
+	}
+	return r.Cmp(lim.min) >= 0 && r.Cmp(lim.max) <= 0
+}
+
+type limit struct {
+	min, max *big.Rat
+	integer  bool
+}
+
+var limits = func() map[string]limit {
+	ints := func(min int64, max uint64) limit {
+		return limit{
+			min:     new(big.Rat).SetInt64(min),
+			max:     new(big.Rat).SetInt(new(big.Int).SetUint64(max)),
+			integer: true,
+		}
+	}
+	floats := func(max float64) limit {
+		m := new(big.Rat).SetFloat64(max)
+		return limit{min: new(big.Rat).Neg(m), max: m}
+	}
+	return map[string]limit{
+		"int":     ints(math.MinInt, math.MaxInt),
+		"int8":    ints(math.MinInt8, math.MaxInt8),
+		"int16":   ints(math.MinInt16, math.MaxInt16),
+		"int32":   ints(math.MinInt32, math.MaxInt32),
+		"int64":   ints(math.MinInt64, math.MaxInt64),
+		"uint":    ints(0, math.MaxUint),
+		"uint8":   ints(0, math.MaxUint8),
+		"uint16":  ints(0, math.MaxUint16),
+		"uint32":  ints(0, math.MaxUint32),
+		"uint64":  ints(0, math.MaxUint64),
+		"float32": floats(math.MaxFloat32),
+		"float64": floats(math.MaxFloat64),
+	}
+}()
+
+func mustNumber(x interface{}) *big.Rat {
+	r, ok := number(x)
+	if !ok {
+		panic(fmt.Errorf("validate: Not a number: %v", x))
+	}
+	return r
+}
+
+// number converts a value to an exact rational, reporting whether it is a
+// finite number. The result must not be modified, since it may be the
+// value itself.
+func number(x interface{}) (*big.Rat, bool) {
+	switch c := x.(type) {
+	case *big.Rat:
+		return c, c != nil
+	case *big.Int:
+		if c == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(c), true
+	case *big.Float:
+		if c == nil || c.IsInf() {
+			return nil, false
+		}
+		r, _ := c.Rat(nil)
+		return r, true
+	}
+	z := reflect.ValueOf(x)
+	switch z.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(z.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(z.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := z.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, z.Type().Bits()))
+		return r, ok
+	case reflect.String:
+		s := z.String()
+		if !decimal(s) {
+			return nil, false
+		}
+		r, ok := new(big.Rat).SetString(strings.TrimPrefix(s, "+"))
+		return r, ok
+	default:
+		return nil, false
+	}
+}
+
+// decimals returns the number of digits after the decimal point of a
+// number, reporting whether it has a finite decimal representation.
+func decimals(x interface{}) (int, bool) {
+	z := reflect.ValueOf(x)
+	switch z.Kind() {
+	case reflect.String:
+		s := z.String()
+		if !decimal(s) {
+			return 0, false
+		}
+		if p := strings.IndexByte(s, '.'); p >= 0 {
+			return len(s) - p - 1, true
+		}
+		return 0, true
+	case reflect.Float32, reflect.Float64:
+		f := z.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+		s := strconv.FormatFloat(f, 'f', -1, z.Type().Bits())
+		if p := strings.IndexByte(s, '.'); p >= 0 {
+			return len(s) - p - 1, true
+		}
+		return 0, true
+	}
+	r, ok := number(x)
+	if !ok {
+		return 0, false
+	}
+	// a fraction has a finite decimal representation when its denominator
+	// has no prime factors other than 2 and 5, and it requires as many
+	// digits as the greater power of those
+	d := new(big.Int).Set(r.Denom())
+	n := [2]int{}
+	for i, p := range []int64{2, 5} {
+		q, m, b := new(big.Int), new(big.Int), big.NewInt(p)
+		for {
+			q.QuoRem(d, b, m)
+			if m.Sign() != 0 {
+				break
+			}
+			d.Set(q)
+			n[i]++
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	return max(n[0], n[1]), true
+}
+
+func decimal(s string) bool {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	i, f, point := strings.Cut(s, ".")
+	return numeric(i) && (!point || numeric(f))
+}
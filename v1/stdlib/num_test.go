@@ -0,0 +1,173 @@
+package stdlib
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumConversion(t *testing.T) {
+	tests := []struct {
+		In     interface{}
+		Expect string
+		OK     bool
+	}{
+		{0, "0", true},
+		{int8(-12), "-12", true},
+		{uint64(math.MaxUint64), "18446744073709551615", true},
+		{0.1, "1/10", true},
+		{float32(0.1), "1/10", true},
+		{1e21, "1000000000000000000000", true},
+		{math.NaN(), "", false},
+		{math.Inf(-1), "", false},
+		{"12.50", "25/2", true},
+		{"+12", "12", true},
+		{"-0.001", "-1/1000", true},
+		{"99999999999999999999.99", "9999999999999999999999/100", true},
+		{"", "", false},
+		{"-", "", false},
+		{".5", "", false},
+		{"5.", "", false},
+		{"1e3", "", false},
+		{"1,000", "", false},
+		{" 1", "", false},
+		{"NaN", "", false},
+		{big.NewInt(-7), "-7", true},
+		{big.NewRat(1, 3), "1/3", true},
+		{new(big.Float).SetFloat64(0.5), "1/2", true},
+		{new(big.Float).SetInf(false), "", false},
+		{(*big.Int)(nil), "", false},
+		{true, "", false},
+		{nil, "", false},
+	}
+	for _, e := range tests {
+		r, ok := number(e.In)
+		if assert.Equal(t, e.OK, ok, "%#v", e.In) && ok {
+			assert.Equal(t, e.Expect, r.RatString(), "%#v", e.In)
+		}
+	}
+}
+
+func TestNumCompare(t *testing.T) {
+	v := Num{}
+	assert.Equal(t, 1, v.Compare("0.01", 0))
+	assert.Equal(t, 0, v.Compare("0.10", 0.1))
+	assert.Equal(t, -1, v.Compare("9007199254740992", "9007199254740993")) // equal as floats
+	assert.Equal(t, 0, v.Compare(big.NewRat(1, 4), "0.25"))
+	assert.Equal(t, 1, v.Compare(uint64(math.MaxUint64), int64(math.MaxInt64)))
+	assert.Panics(t, func() { v.Compare("abc", 0) })
+	assert.Panics(t, func() { v.Compare(0, true) })
+
+	assert.True(t, v.Positive("0.01"))
+	assert.False(t, v.Positive("0.00"))
+	assert.False(t, v.Positive("-0.01"))
+	assert.False(t, v.Positive("abc"))
+	assert.True(t, v.Negative(-1))
+	assert.False(t, v.Negative(0))
+
+	assert.True(t, v.Between("0.01", 0.01, 1000))
+	assert.True(t, v.Between("1000.00", 0.01, 1000))
+	assert.False(t, v.Between("1000.001", 0.01, 1000))
+	assert.False(t, v.Between("0.009", 0.01, 1000))
+	assert.False(t, v.Between("", 0.01, 1000))
+	assert.True(t, v.Between(big.NewInt(5), "1", "10"))
+	assert.Panics(t, func() { v.Between(1, "one", 10) })
+}
+
+func TestNumMultipleOf(t *testing.T) {
+	v := Num{}
+	assert.True(t, v.MultipleOf("0.15", 0.05))
+	assert.True(t, v.MultipleOf(0.3, 0.1)) // not when computed in floating point
+	assert.True(t, v.MultipleOf(-10, 5))
+	assert.True(t, v.MultipleOf(0, 5))
+	assert.False(t, v.MultipleOf("0.151", 0.05))
+	assert.False(t, v.MultipleOf(7, 5))
+	assert.False(t, v.MultipleOf("x", 5))
+	assert.Panics(t, func() { v.MultipleOf(1, 0) })
+	assert.Panics(t, func() { v.MultipleOf(1, "x") })
+}
+
+func TestNumDecimals(t *testing.T) {
+	v := Num{}
+	tests := []struct {
+		In     interface{}
+		Expect int
+		OK     bool
+	}{
+		{"12", 0, true},
+		{"12.50", 2, true},
+		{"12.500", 3, true},
+		{0.1, 1, true},
+		{12.25, 2, true},
+		{float32(0.3), 1, true},
+		{100, 0, true},
+		{big.NewRat(1, 8), 3, true},
+		{big.NewRat(3, 40), 3, true},
+		{big.NewRat(1, 3), 0, false},
+		{math.Inf(1), 0, false},
+		{"abc", 0, false},
+	}
+	for _, e := range tests {
+		d, ok := decimals(e.In)
+		if assert.Equal(t, e.OK, ok, "%#v", e.In) && ok {
+			assert.Equal(t, e.Expect, d, "%#v", e.In)
+		}
+	}
+	assert.True(t, v.MaxDecimals("12.50", 2))
+	assert.False(t, v.MaxDecimals("12.505", 2))
+	assert.True(t, v.MaxDecimals(12, 0))
+	assert.False(t, v.MaxDecimals(big.NewRat(1, 3), 10))
+	assert.Panics(t, func() { v.MaxDecimals("1", -1) })
+	assert.Panics(t, func() { v.MaxDecimals("1", 1.5) })
+}
+
+func TestNumFits(t *testing.T) {
+	v := Num{}
+	tests := []struct {
+		In     interface{}
+		Kind   string
+		Expect bool
+	}{
+		{127, "int8", true},
+		{128, "int8", false},
+		{-128, "int8", true},
+		{-129, "int8", false},
+		{-1, "uint", false},
+		{255.0, "uint8", true},
+		{255.5, "uint8", false},
+		{"4294967295", "uint32", true},
+		{"4294967296", "uint32", false},
+		{"2147483648", "int32", false},
+		{uint64(math.MaxUint64), "uint64", true},
+		{uint64(math.MaxUint64), "int64", false},
+		{"18446744073709551616", "uint64", false},
+		{"1e3", "int", false},
+		{1e39, "float32", false},
+		{1e38, "float32", true},
+		{"0.5", "float32", true},
+		{math.MaxFloat64, "float64", true},
+		{math.Inf(1), "float64", false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Expect, v.Fits(e.In, e.Kind), "%v as %s", e.In, e.Kind)
+	}
+	assert.Panics(t, func() { v.Fits(1, "int128") })
+}
+
+func TestNumPredicates(t *testing.T) {
+	v := Num{}
+	assert.True(t, v.Decimal("-12.50"))
+	assert.False(t, v.Decimal("12.5.0"))
+	assert.False(t, v.Decimal("--1"))
+	assert.True(t, v.Finite(1.5))
+	assert.True(t, v.Finite("1.5"))
+	assert.False(t, v.Finite(math.NaN()))
+	assert.False(t, v.Finite(math.Inf(1)))
+	assert.False(t, v.Finite("Inf"))
+	assert.True(t, v.Integer("12.00"))
+	assert.True(t, v.Integer(3.0))
+	assert.False(t, v.Integer("12.01"))
+	assert.False(t, v.Integer(big.NewRat(1, 2)))
+}
@@ -0,0 +1,43 @@
+package stdlib
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+var (
+	patternsLock sync.RWMutex
+	patterns     = make(map[string]*regexp.Regexp)
+)
+
+// RegisterPattern registers a regular expression by name, so that it can be
+// defined once and used in any number of expressions by way of
+// str.Pattern, as in str.Pattern("sku", self). Patterns are usually
+// registered when a program is initialized; an error is returned if the
+// pattern cannot be compiled or the name has already been registered.
+func RegisterPattern(name, pattern string) error {
+	if name == "" {
+		return fmt.Errorf("Pattern name is empty")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("Invalid pattern %q: %w", name, err)
+	}
+	patternsLock.Lock()
+	defer patternsLock.Unlock()
+	if _, ok := patterns[name]; ok {
+		return fmt.Errorf("Pattern is already registered: %q", name)
+	}
+	patterns[name] = re
+	return nil
+}
+
+// RegisteredPattern returns the pattern registered by the provided name,
+// if there is one.
+func RegisteredPattern(name string) (*regexp.Regexp, bool) {
+	patternsLock.RLock()
+	defer patternsLock.RUnlock()
+	re, ok := patterns[name]
+	return re, ok
+}
@@ -0,0 +1,186 @@
+package stdlib
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Phone validates telephone numbers. It is available to expressions as
+// phone.
+//
+// Numbers are validated against the patterns of the numbers each country
+// has allocated, from the metadata of libphonenumber, so a valid number is
+// one which could be assigned; whether it is in service is not known.
+type Phone struct{}
+
+// E164 reports whether s is a telephone number in the international form
+// of ITU-T E.164: a plus sign followed by a country calling code and a
+// national number, with no spaces or punctuation, like +14155552671. The
+// national number must be valid in a country which has that calling code.
+func (v Phone) E164(s string) bool {
+	if len(s) < 2 || s[0] != '+' || !numeric(s[1:]) {
+		return false
+	}
+	cc, n, ok := callingCode(s[1:])
+	if !ok {
+		return false
+	}
+	for _, r := range callingCodes[cc] {
+		if r.valid(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether s is a valid telephone number of the country whose
+// ISO 3166-1 alpha-2 code is country, as in phone.Valid(self, sup.Country),
+// either in international form, beginning with a plus sign and the
+// country's calling code, or as it is dialled within the country, like
+// 020 7946 0958 in GB. Its digits may be grouped by single spaces, hyphens
+// or dots, and one group may be enclosed in parentheses, as in
+// (415) 555-2671.
+//
+// Countries which share a calling code, like the US and CA, are told apart
+// by the numbers each has allocated, such as its area codes.
+func (v Phone) Valid(s, country string) bool {
+	return v.Normalize(s, country) != ""
+}
+
+// Normalize produces the E.164 form of s, a telephone number of the country
+// whose ISO 3166-1 alpha-2 code is country, written as Valid describes, or
+// the empty string if s is not a valid number of that country. Use it to
+// require that numbers are in E.164 form and belong to a country, as in
+// phone.Normalize(self, sup.Country) == self.
+func (v Phone) Normalize(s, country string) string {
+	r, ok := phoneRegions[country]
+	if !ok {
+		return ""
+	}
+	d, intl, ok := dialled(s)
+	if !ok {
+		return ""
+	}
+	if intl {
+		cc, n, ok := callingCode(d)
+		if !ok || cc != r.code || !r.valid(n) {
+			return ""
+		}
+		return "+" + d
+	}
+	switch {
+	case r.prefix != "" && strings.HasPrefix(d, r.prefix) && r.valid(d[len(r.prefix):]):
+		d = d[len(r.prefix):]
+	case !r.valid(d):
+		return ""
+	}
+	return "+" + r.code + d
+}
+
+// phoneRegion describes the telephone numbers of a country, or of a
+// calling code which is not geographic
+type phoneRegion struct {
+	code    string      // the country calling code
+	prefix  string      // the national prefix, which is dialled before a number within the country
+	pattern *lazyRegexp // the pattern of national significant numbers
+}
+
+func (r *phoneRegion) valid(n string) bool {
+	return r.pattern.MatchString(n)
+}
+
+//go:embed data/phone.txt
+var phoneTable string
+
+var (
+	phoneRegions = make(map[string]*phoneRegion)   // regions by country
+	callingCodes = make(map[string][]*phoneRegion) // regions by calling code, including those which are not geographic
+)
+
+func init() {
+	table(phoneTable, 4, func(f []string) {
+		if !numeric(f[1]) || len(f[1]) > 3 {
+			panic(fmt.Errorf("validate: Invalid calling code: %v", f))
+		}
+		r := &phoneRegion{code: f[1], pattern: newLazyRegexp(f[3])}
+		if f[2] != "-" {
+			r.prefix = f[2]
+		}
+		if f[0] != "001" {
+			phoneRegions[f[0]] = r
+		}
+		callingCodes[r.code] = append(callingCodes[r.code], r)
+	})
+}
+
+// dialled produces the digits of the telephone number s and whether it is
+// in international form, beginning with a plus sign. Digits may be grouped
+// by single separators, and one group may be enclosed in parentheses.
+func dialled(s string) (string, bool, bool) {
+	intl := strings.HasPrefix(s, "+")
+	if intl {
+		s = s[1:]
+	}
+	var b strings.Builder
+	sep := true // the start is treated as a separator, so s may not begin with one
+	group := 0  // 1 within the group in parentheses and 2 after it
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			b.WriteByte(c)
+			sep = false
+		case c == ' ' || c == '-' || c == '.':
+			if sep || group == 1 {
+				return "", false, false
+			}
+			sep = true
+		case c == '(' && group == 0:
+			// parentheses surround a single group, as in (415) 555-2671
+			group, sep = 1, true
+		case c == ')' && group == 1 && !sep:
+			group = 2
+		default:
+			return "", false, false
+		}
+	}
+	if b.Len() == 0 || b.Len() > 17 || sep || group == 1 {
+		return "", false, false
+	}
+	return b.String(), intl, true
+}
+
+// callingCode splits the digits d of an international number into a
+// country calling code and the national number which follows it; calling
+// codes are assigned so that none is a prefix of another
+func callingCode(d string) (string, string, bool) {
+	for i := 1; i <= 3 && i < len(d); i++ {
+		if _, ok := callingCodes[d[:i]]; ok {
+			return d[:i], d[i:], true
+		}
+	}
+	return "", "", false
+}
+
+// lazyRegexp is a regular expression which must match an entire string and
+// which is compiled the first time it is used, since the tables of phone
+// numbers and postal codes have hundreds of them and a program is likely
+// to use few
+type lazyRegexp struct {
+	src  string
+	once sync.Once
+	re   *regexp.Regexp
+}
+
+func newLazyRegexp(src string) *lazyRegexp {
+	return &lazyRegexp{src: `^(?:` + src + `)$`}
+}
+
+func (r *lazyRegexp) MatchString(s string) bool {
+	r.once.Do(func() {
+		r.re = regexp.MustCompile(r.src)
+	})
+	return r.re.MatchString(s)
+}
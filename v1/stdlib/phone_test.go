@@ -0,0 +1,77 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneTable(t *testing.T) {
+	for _, l := range callingCodes {
+		for _, r := range l {
+			assert.NotPanics(t, func() { r.valid("") }, r.pattern.src)
+		}
+	}
+	assert.Len(t, callingCodes["1"], 25) // the North American Numbering Plan
+	assert.Equal(t, "44", phoneRegions["GB"].code)
+	assert.Equal(t, "0", phoneRegions["GB"].prefix)
+}
+
+func TestPhoneE164(t *testing.T) {
+	v := Phone{}
+	assert.True(t, v.E164("+14155552671"))
+	assert.True(t, v.E164("+442079460958"))
+	assert.True(t, v.E164("+33612345678"))
+	assert.True(t, v.E164("+80012345678")) // a universal freephone number, which is not geographic
+	assert.False(t, v.E164("14155552671"))
+	assert.False(t, v.E164("+1 415 555 2671"))
+	assert.False(t, v.E164("+11155552671")) // an area code can't begin with 1
+	assert.False(t, v.E164("+4420794609"))
+	assert.False(t, v.E164("+999123456789")) // an unassigned calling code
+	assert.False(t, v.E164("+"))
+	assert.False(t, v.E164(""))
+}
+
+func TestPhoneValid(t *testing.T) {
+	v := Phone{}
+	for _, e := range []struct {
+		Number, Country, Normal string
+	}{
+		{"+14155552671", "US", "+14155552671"},
+		{"(415) 555-2671", "US", "+14155552671"},
+		{"1 415 555 2671", "US", "+14155552671"},
+		{"415.555.2671", "US", "+14155552671"},
+		{"020 7946 0958", "GB", "+442079460958"},
+		{"+44 20 7946 0958", "GB", "+442079460958"},
+		{"030 123456", "DE", "+4930123456"},
+		{"06 12 34 56 78", "FR", "+33612345678"},
+		{"03-1234-5678", "JP", "+81312345678"},
+		{"+7 912 345-67-89", "RU", "+79123456789"},
+		{"+7 701 234 5678", "KZ", "+77012345678"},
+		{"+1 416 555 2671", "CA", "+14165552671"},
+		{"+44 1481 256789", "GG", "+441481256789"},
+		{"+442079460958", "US", ""},   // a number of another country
+		{"+1 415 555 2671", "CA", ""}, // a number of another country with the same calling code
+		{"416 555 2671", "US", ""},
+		{"+44 20 7946 0958", "GG", ""},
+		{"415)555(2671", "US", ""},
+		{"((415 555 2671", "US", ""},
+		{"(415 555 2671", "US", ""},
+		{"(415) (555) 2671", "US", ""},
+		{"( 415) 555 2671", "US", ""},
+		{"(415 )555 2671", "US", ""},
+		{"()415 555 2671", "US", ""},
+		{"415 555 (2671)", "US", "+14155552671"},
+		{"020 7946 0958", "US", ""},
+		{"415--555-2671", "US", ""},
+		{"-415 555 2671", "US", ""},
+		{"415 555 2671 ", "US", ""},
+		{"415 555 2671 ext. 2", "US", ""},
+		{"+1 415 555 2671", "AQ", ""}, // a country without telephone numbers of its own
+		{"+1 415 555 2671", "ZZ", ""},
+		{"", "US", ""},
+	} {
+		assert.Equal(t, e.Normal, v.Normalize(e.Number, e.Country), e.Number)
+		assert.Equal(t, e.Normal != "", v.Valid(e.Number, e.Country), e.Number)
+	}
+}
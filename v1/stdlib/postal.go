@@ -0,0 +1,53 @@
+package stdlib
+
+import (
+	_ "embed"
+	"regexp"
+)
+
+// Postal validates postal codes. It is available to expressions as postal.
+type Postal struct{}
+
+// Valid reports whether s is a postal code of the country whose ISO 3166-1
+// alpha-2 code is country, as in postal.Valid(self, sup.Country). Codes
+// must be in upper case and written as the country's postal service writes
+// them, like SW1A 1AA in GB or 1234 AB in NL, although the space may be
+// omitted where it separates parts of a code.
+//
+// Only the empty string is the postal code of a country which does not use
+// them, like AE or HK. Codes of a country whose format is not known must
+// consist of 2 to 10 letters and digits, which may be separated by single
+// spaces or hyphens, and nothing is the postal code of a country which is
+// not known at all.
+func (v Postal) Valid(s, country string) bool {
+	p, ok := postalCodes[country]
+	if !ok {
+		if _, ok := countries[country]; !ok {
+			return false
+		}
+		return len(s) >= 2 && len(s) <= 10 && postalCode.MatchString(s)
+	}
+	if p == nil {
+		return s == ""
+	}
+	return p.MatchString(s)
+}
+
+// postalCode is the pattern of the postal codes of countries whose format
+// is not known
+var postalCode = regexp.MustCompile(`^[A-Z0-9]+(?:[ -][A-Z0-9]+)*$`)
+
+//go:embed data/postal.txt
+var postalTable string
+
+var postalCodes = make(map[string]*lazyRegexp) // the pattern of the postal codes of each country, or nil if it has none
+
+func init() {
+	table(postalTable, 2, func(f []string) {
+		var p *lazyRegexp
+		if f[1] != "-" {
+			p = newLazyRegexp(f[1])
+		}
+		postalCodes[f[0]] = p
+	})
+}
@@ -0,0 +1,53 @@
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostalTable(t *testing.T) {
+	for k, e := range postalCodes {
+		if e != nil {
+			assert.NotPanics(t, func() { e.MatchString("") }, k)
+		}
+		_, ok := countries[k]
+		assert.True(t, ok, k)
+	}
+}
+
+func TestPostalValid(t *testing.T) {
+	v := Postal{}
+	for _, e := range []struct {
+		Code, Country string
+		Valid         bool
+	}{
+		{"94105", "US", true},
+		{"94105-1234", "US", true},
+		{"9410", "US", false},
+		{"SW1A 1AA", "GB", true},
+		{"SW1A1AA", "GB", true},
+		{"EC1A 1BB", "GB", true},
+		{"sw1a 1aa", "GB", false},
+		{"SW1A  1AA", "GB", false},
+		{"K1A 0B1", "CA", true},
+		{"D1A 0B1", "CA", false},
+		{"1234 AB", "NL", true},
+		{"0123 AB", "NL", false},
+		{"10115", "DE", true},
+		{"1011", "DE", false},
+		{"00-950", "PL", true},
+		{"1000-001", "PT", true},
+		{"100-0001", "JP", true},
+		{"75008", "FR", true},
+		{"", "HK", true}, // no postal codes
+		{"999077", "HK", false},
+		{"", "US", false},
+		{"AB-12 3", "PA", true}, // the format is not known
+		{"A", "PA", false},
+		{"AB_123", "PA", false},
+		{"12345", "ZZ", false},
+	} {
+		assert.Equal(t, e.Valid, v.Valid(e.Code, e.Country), "%s %s", e.Country, e.Code)
+	}
+}
@@ -0,0 +1,491 @@
+package stdlib
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sec validates values which bear on security: passwords, names which may
+// be used to impersonate others and strings which are later used in file
+// names or shell commands. It is available to expressions as sec.
+//
+// The functions which detect a problem, like Confusable, report true when
+// they find it, so they are used as in sec.Confusable(self) == false.
+type Sec struct{}
+
+// PasswordPolicy describes the passwords which are acceptable. A policy is
+// registered by name with [RegisterPasswordPolicy] and enforced by
+// sec.Password; the policy named default, which requires at least 8
+// characters which are not a common password, is always registered.
+type PasswordPolicy struct {
+	MinLength  int      // the minimum number of characters
+	MaxLength  int      // the maximum number of characters, or zero for no limit
+	Classes    int      // the minimum number of classes of characters, as counted by Sec.Classes
+	MinEntropy float64  // the minimum number of bits of entropy, as estimated by Sec.Entropy
+	Lists      []string // the names of the lists of banned passwords to consult, as Sec.Banned does
+}
+
+func (p PasswordPolicy) allows(s string) bool {
+	n := utf8.RuneCountInString(s)
+	switch {
+	case n < p.MinLength, p.MaxLength > 0 && n > p.MaxLength:
+		return false
+	case p.Classes > 0 && classes(s) < p.Classes:
+		return false
+	case p.MinEntropy > 0 && entropy(s) < p.MinEntropy:
+		return false
+	}
+	for _, e := range p.Lists {
+		l, _ := passwordList(e)
+		if banned(s, l) {
+			return false
+		}
+	}
+	return true
+}
+
+// Password reports whether s is valid UTF-8 which satisfies the password
+// policy named default or, if any policies are named, as in
+// sec.Password(self, "admin"), every one of them. A policy which has not
+// been registered is a configuration error.
+func (v Sec) Password(s string, policies ...interface{}) bool {
+	if len(policies) == 0 {
+		policies = []interface{}{"default"}
+	}
+	p := make([]PasswordPolicy, len(policies))
+	for i, e := range policies {
+		n, _ := e.(string)
+		var ok bool
+		if p[i], ok = passwordPolicy(n); !ok {
+			panic(fmt.Errorf("validate: Unknown password policy: %v", e))
+		}
+	}
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, e := range p {
+		if !e.allows(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Banned reports whether s, ignoring case and any digits and symbols which
+// end it, is on the list of common passwords or, if any lists are named,
+// as in sec.Banned(self, "company"), any one of them. Lists are registered
+// with [RegisterPasswordList]; one which has not been registered is a
+// configuration error.
+func (v Sec) Banned(s string, lists ...interface{}) bool {
+	if len(lists) == 0 {
+		lists = []interface{}{"common"}
+	}
+	l := make([]map[string]struct{}, len(lists))
+	for i, e := range lists {
+		n, _ := e.(string)
+		var ok bool
+		if l[i], ok = passwordList(n); !ok {
+			panic(fmt.Errorf("validate: Unknown password list: %v", e))
+		}
+	}
+	for _, e := range l {
+		if banned(s, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Classes produces the number of classes of characters which occur in s,
+// of lower case letters, upper case letters, digits and everything else
+func (v Sec) Classes(s string) int {
+	return classes(s)
+}
+
+// Entropy produces an estimate of the number of bits of entropy in the
+// password s. Each character contributes as many bits as are needed to
+// choose among the characters of the kinds which occur in s: lower case
+// ASCII letters, upper case ASCII letters, digits, other ASCII characters
+// and, counted as 100 more, characters which are not ASCII. A character
+// which repeats the one before it or continues a sequence, as in aaa or
+// 1234, contributes one bit. The estimate does not recognize words, so it
+// is generous to passwords made of them; consult a list of banned
+// passwords as well.
+func (v Sec) Entropy(s string) float64 {
+	return entropy(s)
+}
+
+// MixedScript reports whether s has letters of more than one script, other
+// than the combinations of Latin, Han and the Japanese or Korean scripts
+// or Bopomofo which are commonly written together, as Unicode Technical
+// Standard #39 describes. Characters common to every script, like digits
+// and punctuation, are disregarded.
+func (v Sec) MixedScript(s string) bool {
+	var scripts []string
+	for _, r := range s {
+		n := script(r)
+		if n == "" || n == "Common" || n == "Inherited" || contains(scripts, n) {
+			continue
+		}
+		scripts = append(scripts, n)
+	}
+	if len(scripts) < 2 {
+		return false
+	}
+	for _, e := range scriptSets {
+		if subset(scripts, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Confusable reports whether s is not ASCII but could be mistaken for an
+// ASCII string, like a name written with Cyrillic letters which look like
+// Latin ones, with fullwidth forms or with invisible characters, which is
+// to say that its skeleton is ASCII
+func (v Sec) Confusable(s string) bool {
+	return !isASCII(s) && isASCII(v.Skeleton(s))
+}
+
+// Skeleton produces the form of s in which characters that are easily
+// confused are replaced by the lower case letter they resemble, so that
+// names which look alike have the same skeleton, as in
+// sec.Skeleton(self) != sec.Skeleton(sup.Owner). Letters of other scripts
+// which look like Latin letters, fullwidth forms, and the digits 0 and 1
+// and the letter I are replaced, invisible characters are removed and
+// every other letter is in lower case.
+func (v Sec) Skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if zeroWidth(r) || bidiControl(r) {
+			continue
+		}
+		if r >= 0xff01 && r <= 0xff5e { // fullwidth ASCII
+			r -= 0xff01 - '!'
+		}
+		if p, ok := confusables[r]; ok {
+			r = p
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// ZeroWidth reports whether s has invisible characters which have no
+// width, like the zero width space. The zero width joiner which combines
+// emoji is one of them, so don't use ZeroWidth to validate text in which
+// emoji are expected.
+func (v Sec) ZeroWidth(s string) bool {
+	return strings.IndexFunc(s, zeroWidth) >= 0
+}
+
+// Bidi reports whether s has characters which control the direction of
+// text, which can make text display in a different order than it is read,
+// like a file name that appears to end in .txt but ends in .exe
+func (v Sec) Bidi(s string) bool {
+	return strings.IndexFunc(s, bidiControl) >= 0
+}
+
+// SafeFilename reports whether s can be used as the name of a file on any
+// common operating system without being interpreted: it has no path
+// separators, characters which Windows prohibits, control characters or
+// invisible characters, it does not begin with a dot, so it is neither .
+// nor .. nor hidden, or with a hyphen, which would be read as an option,
+// and it does not end with a dot or a space. Names which Windows reserves
+// for devices, like CON, LPT1.txt and CON .txt, which Windows reads as CON,
+// are not safe, and no name is longer than 255 bytes.
+func (v Sec) SafeFilename(s string) bool {
+	if s == "" || len(s) > 255 || !utf8.ValidString(s) || s[0] == '.' || s[0] == '-' {
+		return false
+	}
+	if c := s[len(s)-1]; c == '.' || c == ' ' {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\<>:"|?*`, r) || zeroWidth(r) || bidiControl(r) {
+			return false
+		}
+	}
+	n := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		n = s[:i]
+	}
+	return !reservedNames[strings.ToUpper(strings.TrimRight(n, " ."))] // Windows ignores trailing spaces and dots
+}
+
+// SafeArg reports whether s can be used as an argument to a shell command
+// without quoting: it consists only of ASCII letters, digits and the
+// characters @%+=:,./_- and it does not begin with a hyphen, which would
+// be read as an option
+func (v Sec) SafeArg(s string) bool {
+	if s == "" || s[0] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) && strings.IndexByte("@%+=:,./_-", s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	secLock          sync.RWMutex
+	passwordPolicies = map[string]PasswordPolicy{
+		"default": {MinLength: 8, Lists: []string{"common"}},
+	}
+	passwordLists = make(map[string]map[string]struct{})
+)
+
+// RegisterPasswordPolicy registers a password policy by name, so that it
+// can be enforced by sec.Password(self, name). Policies are usually
+// registered when a program is initialized; an error is returned if the
+// name has already been registered, the policy admits no passwords or it
+// names a list of banned passwords which has not been registered.
+func RegisterPasswordPolicy(name string, p PasswordPolicy) error {
+	if name == "" {
+		return fmt.Errorf("Password policy name is empty")
+	}
+	if p.MaxLength > 0 && p.MaxLength < p.MinLength {
+		return fmt.Errorf("Password policy %q admits no passwords: maximum length %d is less than minimum %d", name, p.MaxLength, p.MinLength)
+	}
+	secLock.Lock()
+	defer secLock.Unlock()
+	if _, ok := passwordPolicies[name]; ok {
+		return fmt.Errorf("Password policy is already registered: %q", name)
+	}
+	for _, e := range p.Lists {
+		if _, ok := passwordLists[e]; !ok {
+			return fmt.Errorf("Password policy %q refers to an unknown list: %q", name, e)
+		}
+	}
+	p.Lists = append([]string(nil), p.Lists...)
+	passwordPolicies[name] = p
+	return nil
+}
+
+// RegisterPasswordList registers a list of banned passwords by name, so
+// that it can be consulted by sec.Banned(self, name) and by password
+// policies. Passwords are compared without regard to case. The list named
+// common, of passwords which are among the most commonly used, is always
+// registered; an error is returned if the name has already been
+// registered.
+func RegisterPasswordList(name string, passwords []string) error {
+	if name == "" {
+		return fmt.Errorf("Password list name is empty")
+	}
+	l := make(map[string]struct{}, len(passwords))
+	for _, e := range passwords {
+		l[strings.ToLower(e)] = struct{}{}
+	}
+	secLock.Lock()
+	defer secLock.Unlock()
+	if _, ok := passwordLists[name]; ok {
+		return fmt.Errorf("Password list is already registered: %q", name)
+	}
+	passwordLists[name] = l
+	return nil
+}
+
+func passwordPolicy(name string) (PasswordPolicy, bool) {
+	secLock.RLock()
+	defer secLock.RUnlock()
+	p, ok := passwordPolicies[name]
+	return p, ok
+}
+
+func passwordList(name string) (map[string]struct{}, bool) {
+	secLock.RLock()
+	defer secLock.RUnlock()
+	l, ok := passwordLists[name]
+	return l, ok
+}
+
+var (
+	//go:embed data/passwords.txt
+	commonPasswords string
+	//go:embed data/confusables.txt
+	confusablesTable string
+)
+
+var confusables = make(map[rune]rune)
+
+func init() {
+	var common []string
+	table(commonPasswords, 1, func(f []string) {
+		common = append(common, f[0])
+	})
+	if err := RegisterPasswordList("common", common); err != nil {
+		panic(fmt.Errorf("validate: %v", err))
+	}
+	table(confusablesTable, 2, func(f []string) {
+		r, err := strconv.ParseUint(f[0], 16, 32)
+		if err != nil || utf8.RuneCountInString(f[1]) != 1 {
+			panic(fmt.Errorf("validate: Invalid confusable: %v", f))
+		}
+		confusables[rune(r)], _ = utf8.DecodeRuneInString(f[1])
+	})
+}
+
+// banned reports whether s, or s without the digits and symbols which end
+// it, is on the list l, without regard to case
+func banned(s string, l map[string]struct{}) bool {
+	s = strings.ToLower(s)
+	if _, ok := l[s]; ok {
+		return true
+	}
+	t := strings.TrimRightFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if t == "" || t == s {
+		return false
+	}
+	_, ok := l[t]
+	return ok
+}
+
+func classes(s string) int {
+	var lower, upper, digit, other int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+func entropy(s string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, e := range []struct {
+		ok bool
+		n  int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if e.ok {
+			pool += e.n
+		}
+	}
+	if pool < 2 {
+		return 0
+	}
+	bits := math.Log2(float64(pool))
+	var n float64
+	prev := rune(-1)
+	for _, r := range s {
+		if prev >= 0 && r >= prev-1 && r <= prev+1 {
+			n += 1
+		} else {
+			n += bits
+		}
+		prev = r
+	}
+	return n
+}
+
+// scriptSets are the combinations of scripts which are commonly written
+// together
+var scriptSets = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// commonScripts are the scripts which are considered first, since most
+// names are written in them
+var commonScripts = []string{"Latin", "Cyrillic", "Greek", "Common", "Inherited"}
+
+// otherScripts are the names of the remaining scripts, in order
+var otherScripts = func() []string {
+	var n []string
+	for e := range unicode.Scripts {
+		if !contains(commonScripts, e) {
+			n = append(n, e)
+		}
+	}
+	sort.Strings(n)
+	return n
+}()
+
+// script produces the name of the script of r, or the empty string if r
+// is not assigned to one
+func script(r rune) string {
+	if r < utf8.RuneSelf {
+		if r|0x20 >= 'a' && r|0x20 <= 'z' {
+			return "Latin"
+		}
+		return "Common"
+	}
+	for _, l := range [][]string{commonScripts, otherScripts} {
+		for _, n := range l {
+			if unicode.Is(unicode.Scripts[n], r) {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// subset reports whether every element of a is an element of b
+func subset(a, b []string) bool {
+	for _, e := range a {
+		if !contains(b, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func zeroWidth(r rune) bool {
+	return (r >= 0x200b && r <= 0x200d) || (r >= 0x2060 && r <= 0x2064) || r == 0xfeff || r == 0x180e
+}
+
+func bidiControl(r rune) bool {
+	return r == 0x061c || r == 0x200e || r == 0x200f || (r >= 0x202a && r <= 0x202e) || (r >= 0x2066 && r <= 0x2069)
+}
+
+// reservedNames are the names which Windows reserves for devices
+var reservedNames = func() map[string]bool {
+	n := map[string]bool{"CON": true, "PRN": true, "AUX": true, "NUL": true}
+	for i := 1; i <= 9; i++ {
+		n["COM"+strconv.Itoa(i)], n["LPT"+strconv.Itoa(i)] = true, true
+	}
+	return n
+}()
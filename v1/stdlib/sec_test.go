@@ -0,0 +1,164 @@
+package stdlib
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecPasswords(t *testing.T) {
+	v := Sec{}
+	assert.True(t, v.Password("correct horse battery staple"))
+	assert.True(t, v.Password("Tr0ub4dor&3"))
+	assert.False(t, v.Password("short"))
+	assert.False(t, v.Password("password"))
+	assert.False(t, v.Password("Password123!")) // a common password with digits and symbols appended
+	assert.False(t, v.Password("12345678"))
+	assert.False(t, v.Password("\xffcorrect horse"))
+
+	assert.NoError(t, RegisterPasswordList("test-company", []string{"Acme", "AcmeCorp"}))
+	t.Cleanup(func() { unregisterPasswords("test-company") })
+	assert.Error(t, RegisterPasswordList("test-company", nil))
+	assert.Error(t, RegisterPasswordList("", nil))
+	t.Cleanup(func() { unregisterPasswords("test-admin") })
+	assert.NoError(t, RegisterPasswordPolicy("test-admin", PasswordPolicy{MinLength: 12, MaxLength: 64, Classes: 3, MinEntropy: 60, Lists: []string{"common", "test-company"}}))
+	assert.Error(t, RegisterPasswordPolicy("test-admin", PasswordPolicy{}))
+	assert.Error(t, RegisterPasswordPolicy("test-unknown", PasswordPolicy{Lists: []string{"test-unknown"}}))
+	assert.Error(t, RegisterPasswordPolicy("test-empty", PasswordPolicy{MinLength: 12, MaxLength: 8}))
+
+	assert.True(t, v.Password("Tr0ub4dor&3x", "test-admin"))
+	assert.True(t, v.Password("Tr0ub4dor&3x", "default", "test-admin"))
+	assert.False(t, v.Password("Tr0ub4dor&3", "test-admin"))                 // too short
+	assert.False(t, v.Password("troubadorandthree", "test-admin"))           // too few classes
+	assert.False(t, v.Password("Aa1"+strings.Repeat("b", 20), "test-admin")) // too little entropy
+	assert.False(t, v.Password("Tr0ub4dor&3x"+strings.Repeat("x", 60), "test-admin"))
+	assert.False(t, v.Password("ACMECORP2024!", "test-admin"))
+	assert.Panics(t, func() { v.Password("Tr0ub4dor&3x", "test-unknown") })
+	assert.Panics(t, func() { v.Password("Tr0ub4dor&3x", 1) })
+}
+
+func TestSecBanned(t *testing.T) {
+	v := Sec{}
+	assert.True(t, v.Banned("password"))
+	assert.True(t, v.Banned("PassWord"))
+	assert.True(t, v.Banned("password1!"))
+	assert.True(t, v.Banned("123456"))
+	assert.False(t, v.Banned("1password"))
+	assert.False(t, v.Banned("correct horse battery staple"))
+	assert.False(t, v.Banned("acme", "common"))
+	assert.Panics(t, func() { v.Banned("acme", "test-unknown") })
+}
+
+func TestSecEntropy(t *testing.T) {
+	v := Sec{}
+	assert.Equal(t, 0, v.Classes(""))
+	assert.Equal(t, 1, v.Classes("password"))
+	assert.Equal(t, 4, v.Classes("Password123!"))
+	assert.Equal(t, 2, v.Classes("Пароль"))
+
+	assert.Equal(t, 0.0, v.Entropy(""))
+	assert.InDelta(t, 3.3, v.Entropy("0"), 0.1) // one of ten digits
+	assert.InDelta(t, 33.9, v.Entropy("password"), 0.1)
+	assert.InDelta(t, 10.3, v.Entropy("12345678"), 0.1) // one digit, then a sequence
+	assert.InDelta(t, 11.7, v.Entropy("aaaaaaaa"), 0.1)
+	assert.Greater(t, v.Entropy("correct horse battery staple"), 128.0)
+}
+
+func TestSecConfusables(t *testing.T) {
+	v := Sec{}
+	assert.Equal(t, "paypal", v.Skeleton("PayPaI"))
+	assert.Equal(t, "paypal", v.Skeleton("раураl")) // Cyrillic
+	assert.Equal(t, "paypal", v.Skeleton("ＰａｙＰａｌ"))
+	assert.Equal(t, "paypal", v.Skeleton("pay\u200bpal"))
+	assert.Equal(t, "google", v.Skeleton("goog1e"))
+
+	assert.True(t, v.Confusable("раураl"))
+	assert.True(t, v.Confusable("ＰａｙＰａｌ"))
+	assert.True(t, v.Confusable("pay\u200bpal"))
+	assert.False(t, v.Confusable("paypal"))
+	assert.False(t, v.Confusable("Иван"))
+	assert.False(t, v.Confusable("Café"))
+
+	assert.True(t, v.MixedScript("раураl"))
+	assert.True(t, v.MixedScript("abcαβγ"))
+	assert.False(t, v.MixedScript("paypal"))
+	assert.False(t, v.MixedScript("Иван"))
+	assert.False(t, v.MixedScript("Ελληνικά 2024"))
+	assert.False(t, v.MixedScript("東京tokyo"))
+	assert.False(t, v.MixedScript("日本語ひらがなカタカナ"))
+	assert.False(t, v.MixedScript("한국어English"))
+	assert.True(t, v.MixedScript("ひらがな한국어"))
+
+	assert.True(t, v.ZeroWidth("pay\u200bpal"))
+	assert.True(t, v.ZeroWidth("\ufeffpaypal"))
+	assert.False(t, v.ZeroWidth("paypal"))
+	assert.True(t, v.Bidi("invoice\u202efdp.exe"))
+	assert.True(t, v.Bidi("\u2066abc\u2069"))
+	assert.False(t, v.Bidi("שלום"))
+}
+
+func TestSecScripts(t *testing.T) {
+	for r, n := range map[rune]string{
+		'a':      "Latin",
+		'é':      "Latin",
+		'я':      "Cyrillic",
+		'λ':      "Greek",
+		'1':      "Common",
+		'\u0301': "Inherited",
+		'東':      "Han",
+		'ひ':      "Hiragana",
+		'한':      "Hangul",
+		'\u0378': "", // unassigned
+	} {
+		assert.Equal(t, n, script(r), string(r))
+	}
+	assert.True(t, sort.StringsAreSorted(otherScripts))
+}
+
+func TestSecSafe(t *testing.T) {
+	v := Sec{}
+	assert.True(t, v.SafeFilename("report.pdf"))
+	assert.True(t, v.SafeFilename("Résumé 2024.docx"))
+	assert.True(t, v.SafeFilename("console.log"))
+	assert.False(t, v.SafeFilename(""))
+	assert.False(t, v.SafeFilename("."))
+	assert.False(t, v.SafeFilename(".."))
+	assert.False(t, v.SafeFilename(".htaccess"))
+	assert.False(t, v.SafeFilename("../etc/passwd"))
+	assert.False(t, v.SafeFilename(`C:\autoexec.bat`))
+	assert.False(t, v.SafeFilename("-rf"))
+	assert.False(t, v.SafeFilename("what?.txt"))
+	assert.False(t, v.SafeFilename("report.pdf."))
+	assert.False(t, v.SafeFilename("report.pdf "))
+	assert.False(t, v.SafeFilename("line\nbreak"))
+	assert.False(t, v.SafeFilename("invoice\u202efdp.exe"))
+	assert.False(t, v.SafeFilename("con"))
+	assert.False(t, v.SafeFilename("LPT1.txt"))
+	assert.False(t, v.SafeFilename("CON .txt"))
+	assert.False(t, v.SafeFilename("nul  .tar.gz"))
+	assert.True(t, v.SafeFilename("con sole.txt"))
+	assert.False(t, v.SafeFilename(strings.Repeat("a", 256)))
+	assert.False(t, v.SafeFilename("\xff"))
+
+	assert.True(t, v.SafeArg("report.pdf"))
+	assert.True(t, v.SafeArg("user@example.com"))
+	assert.True(t, v.SafeArg("a=b,c:d/e_f+g%h"))
+	assert.False(t, v.SafeArg(""))
+	assert.False(t, v.SafeArg("-rf"))
+	assert.False(t, v.SafeArg("a b"))
+	assert.False(t, v.SafeArg("$(reboot)"))
+	assert.False(t, v.SafeArg("a;b"))
+	assert.False(t, v.SafeArg("'quoted'"))
+	assert.False(t, v.SafeArg("naïve"))
+}
+
+// unregisterPasswords removes the password list and policy registered by a
+// test under name, so that the test can be run again
+func unregisterPasswords(name string) {
+	secLock.Lock()
+	defer secLock.Unlock()
+	delete(passwordLists, name)
+	delete(passwordPolicies, name)
+}
@@ -0,0 +1,47 @@
+// Package stdlib provides the namespaces of functions which are available
+// to validation expressions, such as str.Alpha(self).
+package stdlib
+
+import (
+	"sort"
+)
+
+var namespaces = map[string]interface{}{
+	"str":    Strings{},
+	"net":    Net{},
+	"time":   Time{},
+	"num":    Num{},
+	"coll":   Coll{},
+	"id":     ID{},
+	"fin":    Fin{},
+	"iso":    ISO{},
+	"sec":    Sec{},
+	"phone":  Phone{},
+	"postal": Postal{},
+}
+
+// Namespace returns the namespace which is available to expressions by the
+// provided name, if there is one.
+func Namespace(name string) (interface{}, bool) {
+	v, ok := namespaces[name]
+	return v, ok
+}
+
+// Message produces the message which describes the failure of a check of
+// the provided expression, when the expression consists of functions which
+// can describe their failures, like coll.MinLen(self, 2), which is
+// described as: Must have at least 2 items. Otherwise it produces the empty
+// string. A message provided by the field's tag takes precedence.
+func Message(expr string) string {
+	return lenMessage(expr)
+}
+
+// Names returns the names of every namespace, sorted.
+func Names() []string {
+	n := make([]string, 0, len(namespaces))
+	for k := range namespaces {
+		n = append(n, k)
+	}
+	sort.Strings(n)
+	return n
+}
@@ -3,7 +3,9 @@ package stdlib
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 func checkString(s string, f func(r rune) bool) bool {
@@ -15,7 +17,13 @@ func checkString(s string, f func(r rune) bool) bool {
 	return true
 }
 
-type Strings struct{}
+// Strings validates strings. It is available to expressions as str.
+type Strings struct {
+	// Compile compiles the patterns provided to Match. Validators set it to
+	// compile patterns by way of their cache; if it is nil, a pattern is
+	// compiled every time it is used.
+	Compile func(string) (*regexp.Regexp, error)
+}
 
 func (v Strings) Alpha(s string) bool {
 	return checkString(s, unicode.IsLetter)
@@ -31,10 +39,127 @@ func (v Strings) AlphaNumeric(s string) bool {
 	})
 }
 
+// Match reports whether s contains a match of the regular expression p.
+// A pattern which cannot be compiled is a configuration error; validators
+// report it when an expression is prepared if p is a string literal.
 func (v Strings) Match(p, s string) bool {
-	m, err := regexp.Match(p, []byte(s))
+	compile := v.Compile
+	if compile == nil {
+		compile = regexp.Compile
+	}
+	re, err := compile(p)
 	if err != nil {
 		panic(fmt.Errorf("validate: Invalid pattern: %s", p))
 	}
-	return m
+	return re.MatchString(s)
+}
+
+// Pattern reports whether s contains a match of the pattern registered by
+// the provided name with [RegisterPattern], as in str.Pattern("sku", self).
+// A name which has not been registered is a configuration error.
+func (v Strings) Pattern(name, s string) bool {
+	re, ok := RegisteredPattern(name)
+	if !ok {
+		panic(fmt.Errorf("validate: Unknown pattern: %s", name))
+	}
+	return re.MatchString(s)
+}
+
+// Len produces the number of characters (code points) in s, unlike len,
+// which produces the number of bytes, so that a limit like
+// str.Len(self) <= 20 admits 20 characters of any script.
+func (v Strings) Len(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// Graphemes produces the number of characters in s as a reader perceives
+// them, so that a letter with a combining accent, a flag or an emoji with
+// a skin tone each count as one. Use it to limit the length of text which
+// is displayed.
+func (v Strings) Graphemes(s string) int {
+	return graphemes(s)
+}
+
+// UTF8 reports whether s is valid UTF-8
+func (v Strings) UTF8(s string) bool {
+	return utf8.ValidString(s)
+}
+
+// ASCII reports whether s consists only of ASCII characters
+func (v Strings) ASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Printable reports whether s is valid UTF-8 which consists only of
+// letters, marks, numbers, punctuation, symbols and spaces, and so has no
+// control, format or unassigned characters
+func (v Strings) Printable(s string) bool {
+	return utf8.ValidString(s) && checkString(s, unicode.IsGraphic)
+}
+
+// NoControl reports whether s is valid UTF-8 which has no control
+// characters, such as NUL, tabs and line breaks
+func (v Strings) NoControl(s string) bool {
+	return utf8.ValidString(s) && checkString(s, func(r rune) bool {
+		return !unicode.IsControl(r)
+	})
+}
+
+// Trimmed reports whether s has no leading or trailing whitespace
+func (v Strings) Trimmed(s string) bool {
+	return s == strings.TrimSpace(s)
+}
+
+// Lower reports whether s has no upper or title case letters
+func (v Strings) Lower(s string) bool {
+	return checkString(s, func(r rune) bool {
+		return !unicode.IsUpper(r) && !unicode.IsTitle(r)
+	})
+}
+
+// Upper reports whether s has no lower or title case letters
+func (v Strings) Upper(s string) bool {
+	return checkString(s, func(r rune) bool {
+		return !unicode.IsLower(r) && !unicode.IsTitle(r)
+	})
+}
+
+// HasPrefix reports whether s begins with prefix
+func (v Strings) HasPrefix(s, prefix string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// HasSuffix reports whether s ends with suffix
+func (v Strings) HasSuffix(s, suffix string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+// Contains reports whether substr is within s
+func (v Strings) Contains(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
+
+// Script reports whether every character of s belongs to one of the named
+// Unicode scripts, as in str.Script(self, "Latin", "Greek"), or is common
+// to all scripts, like spaces, digits, punctuation and combining marks.
+// A script which is not known to the unicode package is a configuration
+// error.
+func (v Strings) Script(s string, scripts ...interface{}) bool {
+	t := []*unicode.RangeTable{unicode.Common, unicode.Inherited}
+	for _, e := range scripts {
+		n, _ := e.(string)
+		r, ok := unicode.Scripts[n]
+		if !ok {
+			panic(fmt.Errorf("validate: Unsupported script: %v", e))
+		}
+		t = append(t, r)
+	}
+	return utf8.ValidString(s) && checkString(s, func(r rune) bool {
+		return unicode.In(r, t...)
+	})
 }
@@ -0,0 +1,131 @@
+package stdlib
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringsLength(t *testing.T) {
+	v := Strings{}
+	tests := []struct {
+		In         string
+		Len, Glyph int
+	}{
+		{"", 0, 0},
+		{"hello", 5, 5},
+		{"山田太郎", 4, 4},
+		{"e\u0301", 2, 1},              // a combining accent
+		{"\r\n", 2, 1},                 // a line break
+		{"a\u200db", 3, 2},             // ZWJ between letters
+		{"\U0001F44D\U0001F3FD", 2, 1}, // a skin tone
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 5, 1}, // a family
+		{"❤\ufe0f", 2, 1}, // an emoji presentation selector
+		{"\U0001F1EF\U0001F1F5\U0001F1FA\U0001F1F8", 4, 2},                               // two flags
+		{"\U0001F1EF\U0001F1F5\U0001F1FA", 3, 2},                                         // an unpaired regional indicator
+		{"\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", 7, 1}, // a flag with tags
+		{"한국어", 3, 3},
+		{"\u1112\u1161\u11ab", 3, 1}, // conjoining jamo
+		{"กำ", 2, 1},                 // a Thai spacing mark
+		{"\u0600١", 2, 1},            // an Arabic prepended mark
+		{"a\tb", 3, 3},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.Len, v.Len(e.In), "Len: %q", e.In)
+		assert.Equal(t, e.Glyph, v.Graphemes(e.In), "Graphemes: %q", e.In)
+	}
+}
+
+func TestStringsClasses(t *testing.T) {
+	v := Strings{}
+	assert.True(t, v.UTF8("山田"))
+	assert.False(t, v.UTF8("\xff"))
+
+	assert.True(t, v.ASCII(""))
+	assert.True(t, v.ASCII("hello, world\n"))
+	assert.False(t, v.ASCII("café"))
+
+	assert.True(t, v.Printable("Hello, 山田! 👍"))
+	assert.True(t, v.Printable("a\u00a0b"))
+	assert.False(t, v.Printable("a\tb"))
+	assert.False(t, v.Printable("a\u202eb")) // a bidirectional override
+	assert.False(t, v.Printable("a\u0378b")) // unassigned
+	assert.False(t, v.Printable("\xff"))
+
+	assert.True(t, v.NoControl("a\u202eb"))
+	assert.False(t, v.NoControl("a\x00b"))
+	assert.False(t, v.NoControl("a\nb"))
+	assert.False(t, v.NoControl("\xff"))
+
+	assert.True(t, v.Trimmed(""))
+	assert.True(t, v.Trimmed("a b"))
+	assert.False(t, v.Trimmed(" a"))
+	assert.False(t, v.Trimmed("a\n"))
+	assert.False(t, v.Trimmed("a\u3000"))
+
+	assert.True(t, v.Lower("hello, world 1"))
+	assert.True(t, v.Lower("straße"))
+	assert.False(t, v.Lower("Hello"))
+	assert.False(t, v.Lower("ǅ")) // title case
+	assert.True(t, v.Upper("HELLO, WORLD 1"))
+	assert.True(t, v.Upper("山田"))
+	assert.False(t, v.Upper("HELLo"))
+}
+
+func TestStringsSubstrings(t *testing.T) {
+	v := Strings{}
+	assert.True(t, v.HasPrefix("sku-123", "sku-"))
+	assert.False(t, v.HasPrefix("sku-123", "SKU-"))
+	assert.True(t, v.HasSuffix("photo.png", ".png"))
+	assert.False(t, v.HasSuffix("photo.png", ".jpg"))
+	assert.True(t, v.Contains("hello, world", "o, w"))
+	assert.True(t, v.Contains("hello", ""))
+	assert.False(t, v.Contains("hello", "world"))
+}
+
+func TestStringsScript(t *testing.T) {
+	v := Strings{}
+	assert.True(t, v.Script("Crème brûlée, 2 €", "Latin"))
+	assert.True(t, v.Script("", "Latin"))
+	assert.False(t, v.Script("Cafeс", "Latin")) // a Cyrillic es
+	assert.True(t, v.Script("Cafeс", "Latin", "Cyrillic"))
+	assert.True(t, v.Script("やまだ タロウ", "Hiragana", "Katakana"))
+	assert.False(t, v.Script("山田", "Hiragana", "Katakana"))
+	assert.True(t, v.Script("123 - 456"))
+	assert.False(t, v.Script("\xff", "Latin"))
+	assert.Panics(t, func() { v.Script("abc", "Klingon") })
+	assert.Panics(t, func() { v.Script("abc", 1) })
+}
+
+func TestStringsPatterns(t *testing.T) {
+	v := Strings{}
+	assert.True(t, v.Match(`^[a-z]+$`, "abc"))
+	assert.False(t, v.Match(`^[a-z]+$`, "ABC"))
+	assert.Panics(t, func() { v.Match(`^[a-z+$`, "abc") })
+
+	var compiled []string
+	v = Strings{Compile: func(p string) (*regexp.Regexp, error) {
+		compiled = append(compiled, p)
+		return regexp.Compile(p)
+	}}
+	assert.True(t, v.Match(`b`, "abc"))
+	assert.Equal(t, []string{"b"}, compiled)
+
+	assert.NoError(t, RegisterPattern("test-sku", `^[A-Z]{3}-[0-9]{4}$`))
+	t.Cleanup(func() { unregisterPattern("test-sku") })
+	assert.Error(t, RegisterPattern("test-sku", `^[A-Z]{3}$`))
+	assert.Error(t, RegisterPattern("test-bad", `^[A-Z+$`))
+	assert.Error(t, RegisterPattern("", `^[A-Z]+$`))
+	assert.True(t, v.Pattern("test-sku", "ABC-1234"))
+	assert.False(t, v.Pattern("test-sku", "abc-1234"))
+	assert.Panics(t, func() { v.Pattern("test-bad", "ABC") })
+}
+
+// unregisterPattern removes a pattern registered by a test, so that the
+// test can be run again
+func unregisterPattern(name string) {
+	patternsLock.Lock()
+	defer patternsLock.Unlock()
+	delete(patterns, name)
+}
@@ -0,0 +1,321 @@
+package stdlib
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // time zones are validated consistently wherever we run
+)
+
+// Time validates times, dates, durations and time zones. It is available
+// to expressions as time.
+type Time struct{}
+
+// Duration parses a duration like 90m or 1h30m. Since an invalid duration
+// causes a panic, which is appropriate for a literal, validate input with
+// IsDuration first, as in: time.IsDuration(self) && time.Duration(self) <= time.Duration("2h")
+func (v Time) Duration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panic(fmt.Errorf("validate: Invalid duration: %s", s))
+	}
+	return d
+}
+
+// IsDuration reports whether s is a duration like 90m or 1h30m
+func (v Time) IsDuration(s string) bool {
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
+
+// RFC3339 reports whether s is a timestamp as described by RFC 3339, like
+// 2024-03-01T09:30:00Z
+func (v Time) RFC3339(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+// ISO8601 reports whether s is a date, or a date and time, as described by
+// ISO 8601 in either its basic or extended format. Calendar, ordinal and
+// week dates are supported, like 2024-03-01, 2024-061 and 2024-W09-5, and
+// may be followed by a time and zone, like T09:30:00.5+01:00.
+func (v Time) ISO8601(s string) bool {
+	date, clock, timed := strings.Cut(s, "T")
+	ext, ok := isoDate(date, timed)
+	if !ok {
+		return false
+	}
+	return !timed || isoTime(clock, ext)
+}
+
+// Between reports whether t is neither before a nor after b
+func (v Time) Between(t, a, b time.Time) bool {
+	return !t.Before(a) && !t.After(b)
+}
+
+// Weekday returns the name of the day of the week of t, like Monday
+func (v Time) Weekday(t time.Time) string {
+	return t.Weekday().String()
+}
+
+// Weekend reports whether t is a Saturday or Sunday
+func (v Time) Weekend(t time.Time) bool {
+	d := t.Weekday()
+	return d == time.Saturday || d == time.Sunday
+}
+
+// BusinessDay reports whether t is a Monday through Friday. Holidays are
+// not considered.
+func (v Time) BusinessDay(t time.Time) bool {
+	return !v.Weekend(t)
+}
+
+// BusinessDays returns the number of business days after the date of a up
+// to and including the date of b, which is negative when b is before a.
+// Holidays are not considered.
+func (v Time) BusinessDays(a, b time.Time) int {
+	return businessDaysBefore(civilDay(b)+1) - businessDaysBefore(civilDay(a)+1)
+}
+
+// Age returns the age in whole years of someone born on the date birth
+func (v Time) Age(birth time.Time) int {
+	return v.AgeAt(birth, time.Now())
+}
+
+// AgeAt returns the age in whole years, as of the time at, of someone born
+// on the date birth. Someone born on February 29 ages on March 1 in years
+// which are not leap years.
+func (v Time) AgeAt(birth, at time.Time) int {
+	at = at.In(birth.Location())
+	n := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		n--
+	}
+	return n
+}
+
+// Zone reports whether s is the name of a time zone in the IANA time zone
+// database, like America/New_York or UTC.
+func (v Time) Zone(s string) bool {
+	if _, ok := zones.Load(s); ok {
+		return true
+	}
+	if s == "" || s == "Local" {
+		return false // these are meaningful to the time package, not the database
+	}
+	if _, err := time.LoadLocation(s); err != nil {
+		return false
+	}
+	zones.Store(s, struct{}{}) // only valid names are stored, so this is bounded by the database
+	return true
+}
+
+var zones sync.Map
+
+// TimeOfDay reports whether s is a time of day like 09:30 or 09:30:15
+func (v Time) TimeOfDay(s string) bool {
+	_, ok := clock(s)
+	return ok
+}
+
+// ClockBetween reports whether the time of day of t, which may be a time
+// or a string like 09:30, is neither before from nor after to. A range
+// which ends before it starts wraps around midnight, as in 22:00 to 06:00.
+// Invalid bounds are a configuration error.
+func (v Time) ClockBetween(t interface{}, from, to string) bool {
+	lo, ok := clock(from)
+	if !ok {
+		panic(fmt.Errorf("validate: Invalid time of day: %s", from))
+	}
+	hi, ok := clock(to)
+	if !ok {
+		panic(fmt.Errorf("validate: Invalid time of day: %s", to))
+	}
+	var x int
+	switch c := t.(type) {
+	case time.Time:
+		h, m, s := c.Clock()
+		x = h*3600 + m*60 + s
+	case string:
+		if x, ok = clock(c); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+	if lo <= hi {
+		return x >= lo && x <= hi
+	} else {
+		return x >= lo || x <= hi
+	}
+}
+
+// clock parses a time of day like 09:30 or 09:30:15 and returns the number
+// of seconds since midnight
+func clock(s string) (int, bool) {
+	var h, m, sec int
+	var ok bool
+	switch len(s) {
+	case 5:
+		h, m, ok = digits(s[0:2], 0, 23), digits(s[3:5], 0, 59), s[2] == ':'
+	case 8:
+		h, m, sec, ok = digits(s[0:2], 0, 23), digits(s[3:5], 0, 59), digits(s[6:8], 0, 59), s[2] == ':' && s[5] == ':'
+	}
+	if !ok || h < 0 || m < 0 || sec < 0 {
+		return 0, false
+	}
+	return h*3600 + m*60 + sec, true
+}
+
+// civilDay returns the number of days between 1970-01-01 and the date of t
+// in its own location
+func civilDay(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// businessDaysBefore returns the number of business days before the civil
+// day n, relative to an arbitrary origin. 1970-01-01 was a Thursday, so the
+// origin is the Monday three days earlier.
+func businessDaysBefore(n int) int {
+	n += 3
+	w := n / 7
+	if n%7 < 0 {
+		w--
+	}
+	return w*5 + min(n-w*7, 5)
+}
+
+// digits parses s, which must consist only of digits, and returns its
+// value if it is within [lo, hi] or -1 otherwise
+func digits(s string, lo, hi int) int {
+	if !numeric(s) {
+		return -1
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if n < lo || n > hi {
+		return -1
+	}
+	return n
+}
+
+// isoDate validates an ISO 8601 date and reports whether it is in the
+// extended format. A date which is followed by a time must be complete.
+func isoDate(s string, timed bool) (bool, bool) {
+	if len(s) < 7 {
+		return false, false
+	}
+	y := digits(s[0:4], 0, 9999)
+	if y < 0 {
+		return false, false
+	}
+	ext := s[4] == '-'
+	rest := s[4:]
+	if ext {
+		rest = s[5:]
+	}
+	switch {
+	case strings.HasPrefix(rest, "W"): // week date
+		w, d := rest[1:], ""
+		if ext && len(w) > 2 {
+			if w[2] != '-' {
+				return false, false
+			}
+			w, d = w[:2], w[3:]
+		} else if !ext && len(w) > 2 {
+			w, d = w[:2], w[2:]
+		}
+		if len(w) != 2 || (d == "" && timed) {
+			return false, false
+		}
+		_, weeks := time.Date(y, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+		if digits(w, 1, weeks) < 0 || (d != "" && (len(d) != 1 || digits(d, 1, 7) < 0)) {
+			return false, false
+		}
+		return ext, true
+	case len(rest) == 3: // ordinal date
+		return ext, digits(rest, 1, 365+leap(y)) > 0
+	case ext && len(rest) == 2 && !timed: // reduced precision
+		return ext, digits(rest, 1, 12) > 0
+	case ext && len(rest) == 5 && rest[2] == '-', !ext && len(rest) == 4: // calendar date
+		m := digits(rest[0:2], 1, 12)
+		if m < 0 {
+			return false, false
+		}
+		days := time.Date(y, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		return ext, digits(rest[len(rest)-2:], 1, days) > 0
+	default:
+		return false, false
+	}
+}
+
+func leap(y int) int {
+	if y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		return 1
+	}
+	return 0
+}
+
+// isoTime validates an ISO 8601 time of day, with an optional fraction and
+// zone, in the extended or basic format.
+func isoTime(s string, ext bool) bool {
+	if z := strings.IndexAny(s, "Z+-"); z >= 0 {
+		if !isoZone(s[z:], ext) {
+			return false
+		}
+		s = s[:z]
+	}
+	midnight := true
+	if x := strings.IndexAny(s, ".,"); x >= 0 {
+		if !numeric(s[x+1:]) {
+			return false
+		}
+		midnight = strings.Trim(s[x+1:], "0") == ""
+		s = s[:x]
+	}
+	var parts []string
+	if ext {
+		parts = strings.Split(s, ":")
+	} else {
+		for len(s) >= 2 && len(parts) < 3 {
+			parts, s = append(parts, s[:2]), s[2:]
+		}
+		if s != "" {
+			return false
+		}
+	}
+	if len(parts) < 1 || len(parts) > 3 || (ext && len(parts) < 2) {
+		return false
+	}
+	limits := []int{24, 59, 60} // 60 is a leap second
+	for i, e := range parts {
+		n := digits(e, 0, limits[i])
+		if len(e) != 2 || n < 0 {
+			return false
+		}
+		if i > 0 && n != 0 {
+			midnight = false
+		}
+	}
+	// 24 is only permitted as the end of a day, 24:00:00
+	return digits(parts[0], 0, 23) >= 0 || midnight
+}
+
+func isoZone(s string, ext bool) bool {
+	if s == "Z" {
+		return true
+	}
+	s = s[1:]
+	switch {
+	case len(s) == 2:
+		return digits(s, 0, 23) >= 0
+	case ext && len(s) == 5 && s[2] == ':', !ext && len(s) == 4:
+		return digits(s[0:2], 0, 23) >= 0 && digits(s[len(s)-2:], 0, 59) >= 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,146 @@
+package stdlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeFormats(t *testing.T) {
+	v := Time{}
+	tests := []struct {
+		In               string
+		RFC3339, ISO8601 bool
+	}{
+		{"", false, false},
+		{"2024-03-01T09:30:00Z", true, true},
+		{"2024-03-01T09:30:00.123456789+01:00", true, true},
+		{"2024-03-01T09:30:00", false, true},
+		{"2024-03-01", false, true},
+		{"20240301", false, true},
+		{"20240301T093000Z", false, true},
+		{"20240301T0930+0100", false, true},
+		{"2024-03-01T0930", false, false},
+		{"20240301T09:30", false, false},
+		{"2024-03", false, true},
+		{"2024-03T09:30", false, false},
+		{"2024-061", false, true},
+		{"2024366", false, true},
+		{"2023-366", false, false},
+		{"2024-W09-5", false, true},
+		{"2024W095", false, true},
+		{"2024-W09", false, true},
+		{"2020-W53-1", false, true},
+		{"2024-W53-1", false, false},
+		{"2024-W09-8", false, false},
+		{"2024-W09-5T12:00Z", false, true},
+		{"2024-02-29", false, true},
+		{"2023-02-29", false, false},
+		{"2024-13-01", false, false},
+		{"2024-03-01T24:00:00", false, true},
+		{"2024-03-01T24:00:01", false, false},
+		{"2024-03-01T24:00:00.5", false, false},
+		{"2024-03-01T23:59:60Z", false, true},
+		{"2024-03-01T09:30:00,5-05", false, true},
+		{"2024-03-01T09:30:00+1:00", false, false},
+		{"2024-03-01T09:61:00Z", false, false},
+		{"2024-03-01 09:30:00Z", false, false},
+	}
+	for _, e := range tests {
+		assert.Equal(t, e.RFC3339, v.RFC3339(e.In), "RFC3339: %s", e.In)
+		assert.Equal(t, e.ISO8601, v.ISO8601(e.In), "ISO8601: %s", e.In)
+	}
+}
+
+func TestTimeDurations(t *testing.T) {
+	v := Time{}
+	assert.Equal(t, 90*time.Minute, v.Duration("90m"))
+	assert.Equal(t, 90*time.Minute, v.Duration("1h30m"))
+	assert.True(t, v.IsDuration("1h30m"))
+	assert.False(t, v.IsDuration("90"))
+	assert.False(t, v.IsDuration(""))
+	assert.Panics(t, func() { v.Duration("soon") })
+}
+
+func TestTimeDays(t *testing.T) {
+	v := Time{}
+	date := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 12, 0, 0, 0, time.UTC)
+	}
+	assert.True(t, v.Between(date(2024, 3, 1), date(2024, 3, 1), date(2024, 3, 2)))
+	assert.True(t, v.Between(date(2024, 3, 2), date(2024, 3, 1), date(2024, 3, 2)))
+	assert.False(t, v.Between(date(2024, 3, 3), date(2024, 3, 1), date(2024, 3, 2)))
+
+	assert.Equal(t, "Friday", v.Weekday(date(2024, 3, 1)))
+	assert.False(t, v.Weekend(date(2024, 3, 1)))
+	assert.True(t, v.Weekend(date(2024, 3, 2)))
+	assert.True(t, v.Weekend(date(2024, 3, 3)))
+	assert.True(t, v.BusinessDay(date(2024, 3, 4)))
+	assert.False(t, v.BusinessDay(date(2024, 3, 3)))
+
+	// brute force the count for every pair of days over a few weeks,
+	// including dates before the epoch
+	for _, base := range []time.Time{date(2024, 2, 26), date(1969, 12, 20)} {
+		for i := 0; i < 21; i++ {
+			for j := 0; j < 21; j++ {
+				a, b := base.AddDate(0, 0, i), base.AddDate(0, 0, j)
+				n := 0
+				for d := a; d.Before(b); d = d.AddDate(0, 0, 1) {
+					if v.BusinessDay(d.AddDate(0, 0, 1)) {
+						n++
+					}
+				}
+				for d := b; d.Before(a); d = d.AddDate(0, 0, 1) {
+					if v.BusinessDay(d.AddDate(0, 0, 1)) {
+						n--
+					}
+				}
+				assert.Equal(t, n, v.BusinessDays(a, b), "%v → %v", a, b)
+			}
+		}
+	}
+}
+
+func TestTimeAge(t *testing.T) {
+	v := Time{}
+	birth := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 0, v.AgeAt(birth, time.Date(2001, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1, v.AgeAt(birth, time.Date(2001, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 3, v.AgeAt(birth, time.Date(2004, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 4, v.AgeAt(birth, time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, v.AgeAt(birth, time.Date(1999, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, v.Age(birth) >= 24)
+}
+
+func TestTimeZones(t *testing.T) {
+	v := Time{}
+	for _, e := range []string{"UTC", "America/New_York", "Europe/London", "Asia/Kolkata"} {
+		assert.True(t, v.Zone(e), e)
+		assert.True(t, v.Zone(e), e) // cached
+	}
+	for _, e := range []string{"", "Local", "Mars/Olympus_Mons", "../etc/passwd", "america/new_york"} {
+		assert.False(t, v.Zone(e), e)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	v := Time{}
+	for _, e := range []string{"00:00", "09:30", "23:59", "23:59:59"} {
+		assert.True(t, v.TimeOfDay(e), e)
+	}
+	for _, e := range []string{"", "9:30", "24:00", "09:60", "09:30:60", "0930", "09-30"} {
+		assert.False(t, v.TimeOfDay(e), e)
+	}
+
+	assert.True(t, v.ClockBetween("09:00", "09:00", "17:00"))
+	assert.True(t, v.ClockBetween("17:00", "09:00", "17:00"))
+	assert.False(t, v.ClockBetween("17:00:01", "09:00", "17:00"))
+	assert.True(t, v.ClockBetween("23:00", "22:00", "06:00"))
+	assert.True(t, v.ClockBetween("05:00", "22:00", "06:00"))
+	assert.False(t, v.ClockBetween("12:00", "22:00", "06:00"))
+	assert.False(t, v.ClockBetween("noon", "09:00", "17:00"))
+	assert.False(t, v.ClockBetween(12, "09:00", "17:00"))
+	assert.True(t, v.ClockBetween(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), "09:00", "17:00"))
+	assert.Panics(t, func() { v.ClockBetween("12:00", "9am", "17:00") })
+}
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Step describes a single check that was performed while validating a
+// value, along with the checks that were performed as part of it, such as
+// the fields validated by an expression which invokes check(self).
+type Step struct {
+	Path         string        // the path to the checked value
+	Expr         string        // the expression source; empty for introspectors and elements
+	Value        interface{}   // the value of self, if it could be obtained
+	Valid        bool          // whether the check was satisfied
+	Introspector bool          // whether the check is an introspector's Validate method
+	Duration     time.Duration // the time taken to perform the check, including its sub-steps
+	Steps        []*Step       // the checks performed as part of this one
+}
+
+// Trace describes how a value was validated: the result, and every check
+// that was performed to arrive at it.
+type Trace struct {
+	Result
+	Steps    []*Step
+	Duration time.Duration
+}
+
+// Explain validates the provided value and returns a trace describing
+// every check that was evaluated, in addition to the result. This is
+// considerably slower than [Validator.Evaluate] and is intended to help
+// understand why a value is, or is not, valid.
+func (v Validator) Explain(s interface{}) *Trace {
+	tr := &tracer{}
+	start := time.Now()
+	res := v.run(s, tr)
+	return &Trace{
+		Result:   res,
+		Steps:    tr.steps,
+		Duration: time.Since(start),
+	}
+}
+
+// LogValue is implemented so that a trace is logged as structured
+// attributes, including the indented report.
+func (t *Trace) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("errors", t.Errors),
+		slog.Any("warnings", t.Warnings),
+		slog.Duration("duration", t.Duration),
+		slog.String("report", t.String()),
+	)
+}
+
+// String formats the trace as an indented report.
+func (t *Trace) String() string {
+	b := &strings.Builder{}
+	t.WriteTo(b)
+	return b.String()
+}
+
+// WriteTo writes the trace to the provided writer as an indented report.
+func (t *Trace) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	fmt.Fprintf(cw, "%d errors, %d warnings in %v\n", len(t.Errors), len(t.Warnings), t.Duration)
+	for _, e := range t.Steps {
+		e.write(cw, 1)
+	}
+	return cw.n, cw.err
+}
+
+func (s *Step) write(w io.Writer, depth int) {
+	status := "pass"
+	if !s.Valid {
+		status = "FAIL"
+	}
+	var desc string
+	switch {
+	case s.Introspector:
+		desc = fmt.Sprintf("%T.Validate()", s.Value)
+	case s.Expr != "":
+		desc = s.Expr
+	default:
+		desc = "element"
+	}
+	fmt.Fprintf(w, "%s%s %s: %s; self = %s (%v)\n", strings.Repeat("  ", depth), status, coalesce(s.Path, "<root>"), desc, formatValue(s.Value), s.Duration)
+	for _, e := range s.Steps {
+		e.write(w, depth+1)
+	}
+}
+
+func formatValue(v interface{}) string {
+	switch c := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", c)
+	default:
+		return fmt.Sprintf("%v", c)
+	}
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	w.err = err
+	return n, err
+}
+
+// tracer accumulates steps as a value is validated
+type tracer struct {
+	steps []*Step
+	stack []*Step
+}
+
+func (t *tracer) begin(path, expr string, val reflect.Value, intro bool) *Step {
+	s := &Step{
+		Path:         path,
+		Expr:         expr,
+		Introspector: intro,
+	}
+	if val.IsValid() && val.CanInterface() {
+		s.Value = val.Interface()
+	}
+	if l := len(t.stack); l > 0 {
+		top := t.stack[l-1]
+		top.Steps = append(top.Steps, s)
+	} else {
+		t.steps = append(t.steps, s)
+	}
+	t.stack = append(t.stack, s)
+	return s
+}
+
+func (t *tracer) end(s *Step, valid bool, d time.Duration) {
+	s.Valid = valid
+	s.Duration = d
+	if l := len(t.stack); l > 0 {
+		t.stack = t.stack[:l-1]
+	}
+}
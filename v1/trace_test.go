@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	v := New()
+
+	tr := v.Explain(testH{&testB{&testA{}}})
+	assert.Equal(t, []string{"h_1.b_1.a_1", "h_1.b_1", "h_1"}, tr.Errors.Fields())
+	if assert.Len(t, tr.Steps, 1) {
+		s := tr.Steps[0]
+		assert.Equal(t, "h_1", s.Path)
+		assert.Equal(t, "self != nil && check(self)", s.Expr)
+		assert.False(t, s.Valid)
+		if assert.Len(t, s.Steps, 1) && assert.Len(t, s.Steps[0].Steps, 2) { // testA is an introspector
+			assert.True(t, s.Steps[0].Steps[0].Introspector)
+			s = s.Steps[0].Steps[1]
+			assert.Equal(t, "h_1.b_1.a_1", s.Path)
+			assert.Equal(t, "", s.Value)
+			assert.False(t, s.Valid)
+		}
+	}
+
+	tr = v.Explain(testO{F1: "111"})
+	if assert.Len(t, tr.Steps, 2) {
+		assert.True(t, tr.Steps[0].Introspector)
+		assert.False(t, tr.Steps[0].Valid)
+		assert.Equal(t, "o_1", tr.Steps[1].Path)
+		assert.True(t, tr.Steps[1].Valid)
+	}
+
+	tr = v.Explain(testU{testA{"A"}, testA{}})
+	if assert.Len(t, tr.Steps, 2) {
+		assert.Equal(t, "[1]", tr.Steps[1].Path)
+		assert.False(t, tr.Steps[1].Valid)
+		assert.Len(t, tr.Steps[1].Steps, 2)
+	}
+
+	report := tr.String()
+	assert.True(t, strings.HasPrefix(report, "1 errors, 0 warnings in "), report)
+	assert.Contains(t, report, "\n    FAIL [1].a_1: len(self) > 0; self = \"\" (")
+}
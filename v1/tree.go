@@ -0,0 +1,200 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// TreeMessagesKey is the key under which the messages of a tree node are
+// serialized when that node also has children. Nodes with only messages
+// are serialized as a plain array of message strings.
+const TreeMessagesKey = "_errors"
+
+// ErrorTree is a hierarchical representation of a set of errors which
+// mirrors the structure of the validated value: struct fields and map
+// keys become object keys, slice and array elements become indexes, and
+// messages are found at the leaves.
+type ErrorTree struct {
+	Messages []string
+	Fields   map[string]*ErrorTree
+	Items    map[int]*ErrorTree
+}
+
+// Tree arranges the receiver into an error tree. Errors which are not
+// field errors, or which do not identify a field, are attached to the
+// root of the tree. An error reported for alternate fields, like
+// "{a,b}", is attached to each of the alternates.
+func (e Errors) Tree() *ErrorTree {
+	root := &ErrorTree{}
+	for _, err := range e {
+		if c, ok := asFieldError(err); ok {
+			root.add(parsePath(c.Field), c.Message)
+		} else {
+			root.add(nil, err.Error())
+		}
+	}
+	return root
+}
+
+func (t *ErrorTree) add(path []pathElem, msg string) {
+	if len(path) == 0 {
+		t.Messages = append(t.Messages, msg)
+		return
+	}
+	head, rest := path[0], path[1:]
+	if head.IsIndex() {
+		if t.Items == nil {
+			t.Items = make(map[int]*ErrorTree)
+		}
+		t.Items[head.Index] = t.Items[head.Index].or().with(rest, msg)
+		return
+	}
+	for _, k := range head.Alternates() {
+		if t.Fields == nil {
+			t.Fields = make(map[string]*ErrorTree)
+		}
+		t.Fields[k] = t.Fields[k].or().with(rest, msg)
+	}
+}
+
+func (t *ErrorTree) or() *ErrorTree {
+	if t != nil {
+		return t
+	} else {
+		return &ErrorTree{}
+	}
+}
+
+func (t *ErrorTree) with(path []pathElem, msg string) *ErrorTree {
+	t.add(path, msg)
+	return t
+}
+
+// Len returns the number of messages in the tree, including those of its
+// descendants.
+func (t *ErrorTree) Len() int {
+	if t == nil {
+		return 0
+	}
+	n := len(t.Messages)
+	for _, e := range t.Fields {
+		n += e.Len()
+	}
+	for _, e := range t.Items {
+		n += e.Len()
+	}
+	return n
+}
+
+// MarshalJSON serializes the tree. A node with only messages becomes an
+// array of strings, a node with only items becomes an array in which
+// elements without errors are null, and a node with only fields becomes
+// an object. Any other node becomes an object in which items are keyed by
+// their index and messages are keyed by [TreeMessagesKey]; so does a node
+// whose items are sparse, like one with an error only at index 100000,
+// which would otherwise become an array of mostly nulls.
+func (t *ErrorTree) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("null"), nil
+	}
+	nm, nf, ni := len(t.Messages), len(t.Fields), len(t.Items)
+	switch {
+	case nf == 0 && ni == 0:
+		if nm == 0 {
+			return []byte("{}"), nil
+		}
+		return json.Marshal(t.Messages)
+	case nm == 0 && nf == 0 && t.dense():
+		return json.Marshal(t.itemSlice())
+	case nm == 0 && ni == 0:
+		return json.Marshal(t.Fields)
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	n := 0
+	member := func(k string, v interface{}) error {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		n++
+		d, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		buf.Write(d)
+		buf.WriteByte(':')
+		d, err = json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(d)
+		return nil
+	}
+	if nm > 0 {
+		if err := member(TreeMessagesKey, t.Messages); err != nil {
+			return nil, err
+		}
+	}
+	for _, k := range sortedKeys(t.Fields) {
+		if err := member(k, t.Fields[k]); err != nil {
+			return nil, err
+		}
+	}
+	for _, x := range sortedIndexes(t.Items) {
+		if err := member(strconv.Itoa(x), t.Items[x]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// maxSparseItems is the number of elements without errors an array of
+// items may have beyond twice the number with errors
+const maxSparseItems = 16
+
+// dense determines whether the items of the tree are dense enough to be
+// serialized as an array
+func (t *ErrorTree) dense() bool {
+	for x := range t.Items {
+		if x < 0 || x >= 2*len(t.Items)+maxSparseItems {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *ErrorTree) itemSlice() []*ErrorTree {
+	var l int
+	for x := range t.Items {
+		if x >= l {
+			l = x + 1
+		}
+	}
+	items := make([]*ErrorTree, l)
+	for x, e := range t.Items {
+		items[x] = e
+	}
+	return items
+}
+
+func sortedKeys(m map[string]*ErrorTree) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIndexes(m map[int]*ErrorTree) []int {
+	idx := make([]int, 0, len(m))
+	for x := range m {
+		idx = append(idx, x)
+	}
+	sort.Ints(idx)
+	return idx
+}
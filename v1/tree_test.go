@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorTree(t *testing.T) {
+	tests := []struct {
+		Errors Errors
+		Expect string
+	}{
+		{
+			Errors{},
+			`{}`,
+		},
+		{
+			Errors{FieldErrorf("address.zip", "invalid")},
+			`{"address":{"zip":["invalid"]}}`,
+		},
+		{
+			Errors{FieldErrorf("items[2].name", "empty"), FieldErrorf("items[0]", "bad")},
+			`{"items":[["bad"],null,{"name":["empty"]}]}`,
+		},
+		{
+			Errors{FieldErrorf("b_1.a_1", "empty"), FieldErrorf("b_1", "invalid")},
+			`{"b_1":{"_errors":["invalid"],"a_1":["empty"]}}`,
+		},
+		{
+			Errors{FieldErrorf("{f1,first}", "too big")},
+			`{"f1":["too big"],"first":["too big"]}`,
+		},
+		{
+			Errors{newFieldError(entityPath, errors.New("nope")), FieldErrorf("a", "bad")},
+			`{"_errors":["nope"],"a":["bad"]}`,
+		},
+		{
+			Errors{FieldErrorf("[1].a_1", "empty")},
+			`[null,{"a_1":["empty"]}]`,
+		},
+		{
+			Errors{FieldErrorf("x[999999999]", "bad"), FieldErrorf("x[1]", "empty")},
+			`{"x":{"1":["empty"],"999999999":["bad"]}}`,
+		},
+	}
+	for _, e := range tests {
+		data, err := json.Marshal(e.Errors.Tree())
+		if assert.NoError(t, err) {
+			assert.Equal(t, e.Expect, string(data))
+		}
+	}
+}
+
+func TestErrorTreeFromValidate(t *testing.T) {
+	errs := New().Validate(testH{&testB{&testA{}}})
+	tree := errs.Tree()
+	assert.Equal(t, 3, tree.Len())
+	data, err := json.Marshal(tree)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"h_1":{"_errors":["Constraint not satisfied: self != nil && check(self)"],"b_1":{"_errors":["Constraint not satisfied: self != nil && check(self)"],"a_1":["Constraint not satisfied: len(self) > 0"]}}}`, string(data))
+	}
+}
@@ -1,43 +1,55 @@
 package validate
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/bww/go-validate/v1/stdlib"
 )
 
+// Tags describes the names of the tags which determine how a type is
+// validated.
+type Tags struct {
+	Check, Error, Field, Severity, Deprecated string
+}
+
 type typeKey struct {
-	Type   reflect.Type
-	Config Config
+	Type reflect.Type
+	Tags Tags
 }
 
 func newTypeKey(t reflect.Type, v Validator) typeKey {
 	return typeKey{
 		Type: t,
-		Config: Config{
-			CheckTag: v.checkTag,
-			ErrorTag: v.errTag,
-			FieldTag: v.nameTag,
-		},
+		Tags: v.Tags(),
 	}
 }
 
 type validatedField struct {
-	Name    string
-	Message string
-	Noerr   bool // skip error output; this error is reported by a sub-validation
-	Expr    string
-	Index   int
-	Field   reflect.StructField
+	Name        string
+	Message     string
+	Noerr       bool // skip error output; this error is reported by a sub-validation
+	Severity    Severity
+	Deprecated  bool
+	Deprecation string // the deprecation message, if any
+	Unchecked   bool   // the field is only present to report deprecated usage
+	Subtree     bool   // the field recurses via check() and may be validated concurrently with its siblings
+	Expr        string
+	Index       int
+	Field       reflect.StructField
 }
 
 type validatedType struct {
-	Type   reflect.Type
-	Fields []validatedField
+	Type     reflect.Type
+	Fields   []validatedField
+	Subtrees int // the number of fields which are subtrees
 }
 
-func newType(t reflect.Type, v Validator) *validatedType {
+func newType(t reflect.Type, v Validator) (*validatedType, error) {
 	n := t.NumField()
 	f := make([]validatedField, 0, n)
+	subtrees := 0
 
 	for i := 0; i < n; i++ {
 		x := t.Field(i)
@@ -55,27 +67,59 @@ func newType(t reflect.Type, v Validator) *validatedType {
 			noerr = true
 		}
 
+		var dep bool
+		var depmsg string
+		if v.depTag != "" {
+			depmsg, dep = x.Tag.Lookup(v.depTag)
+			depmsg = strings.TrimSpace(depmsg)
+		}
+
+		var unchecked bool
 		src := strings.TrimSpace(getTag(x.Tag, v.checkTag))
-		if src == "-" {
-			continue
-		} else if src == "" && !x.Anonymous {
-			continue
+		if src == "-" || (src == "" && !x.Anonymous) {
+			if !dep {
+				continue
+			}
+			unchecked = true // we only need to report deprecated usage
+		}
+		if msg == "" {
+			msg = stdlib.Message(src)
+		}
+
+		var sev Severity
+		if v.sevTag != "" {
+			var err error
+			sev, err = parseSeverity(x.Tag.Get(v.sevTag))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid severity for field: [%s] %v", x.Name, err)
+			}
+		}
+
+		subtree := !unchecked && !x.Anonymous && (src == "check" || invokesCheck.MatchString(src))
+		if subtree {
+			subtrees++
 		}
 
 		f = append(f, validatedField{
-			Name:    name,
-			Message: msg,
-			Noerr:   noerr,
-			Expr:    src,
-			Index:   i,
-			Field:   x,
+			Name:        name,
+			Message:     msg,
+			Noerr:       noerr,
+			Severity:    sev,
+			Deprecated:  dep,
+			Deprecation: depmsg,
+			Unchecked:   unchecked,
+			Subtree:     subtree,
+			Expr:        src,
+			Index:       i,
+			Field:       x,
 		})
 	}
 
 	return &validatedType{
-		Type:   t,
-		Fields: f,
-	}
+		Type:     t,
+		Fields:   f,
+		Subtrees: subtrees,
+	}, nil
 }
 
 func fieldName(t string) string {
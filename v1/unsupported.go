@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"reflect"
+)
+
+// UnsupportedPolicy determines how values of types which cannot be
+// validated, such as channels and functions, are handled.
+type UnsupportedPolicy int
+
+const (
+	// UnsupportedDefault panics when the environment variable VALIDATE_STRICT
+	// is set and otherwise behaves like UnsupportedWarn.
+	UnsupportedDefault UnsupportedPolicy = iota
+	// UnsupportedIgnore considers unsupported values valid.
+	UnsupportedIgnore
+	// UnsupportedWarn logs a warning and considers unsupported values valid.
+	UnsupportedWarn
+	// UnsupportedReport reports a field error for unsupported values.
+	UnsupportedReport
+	// UnsupportedPanic panics when an unsupported value is encountered, which
+	// is considered a configuration error.
+	UnsupportedPanic
+)
+
+func (p UnsupportedPolicy) resolve() UnsupportedPolicy {
+	if p != UnsupportedDefault {
+		return p
+	} else if strict {
+		return UnsupportedPanic
+	} else {
+		return UnsupportedWarn
+	}
+}
+
+// KindHandler validates a value of a kind that is not otherwise
+// supported. A non-nil error is reported for the path described by the
+// context in the same way as an error returned by an introspector.
+type KindHandler func(Validator, Context, reflect.Value) error
@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unsupportedA struct {
+	F1 chan int `json:"a_1" check:"check"`
+	F2 func()   `json:"a_2" check:"check"`
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	val := unsupportedA{make(chan int), func() {}}
+
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewTextHandler(buf, nil))
+	assert.Len(t, New(Logger(log), UnsupportedTypes(UnsupportedIgnore)).Validate(val), 0)
+	assert.Equal(t, "", buf.String())
+	assert.Len(t, New(Logger(log), UnsupportedTypes(UnsupportedWarn)).Validate(val), 0)
+	assert.Contains(t, buf.String(), "Ignoring unsupported type")
+
+	errs := New(UnsupportedTypes(UnsupportedReport)).Validate(val)
+	assert.Equal(t, []string{"a_1", "a_2"}, errs.Fields())
+	assert.Equal(t, []string{"Unsupported type: chan int", "Unsupported type: func()"}, errs.Messages())
+
+	assert.Panics(t, func() { New(UnsupportedTypes(UnsupportedPanic)).Validate(val) })
+
+	v := New(UnsupportedTypes(UnsupportedPanic), HandleKind(reflect.Chan, func(v Validator, c Context, val reflect.Value) error {
+		if val.IsNil() || val.Cap() < 1 {
+			return fmt.Errorf("Channel must be buffered")
+		}
+		return nil
+	}), HandleKind(reflect.Func, func(v Validator, c Context, val reflect.Value) error {
+		return nil
+	}))
+	errs = v.Validate(val)
+	assert.Equal(t, []string{"a_1"}, errs.Fields())
+	assert.Equal(t, "Channel must be buffered", errs.Messages()[0])
+	assert.Len(t, v.Validate(unsupportedA{make(chan int, 1), nil}), 0)
+}
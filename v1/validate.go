@@ -3,23 +3,24 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bww/epl/v1"
-	"github.com/bww/go-validate/v1/stdlib"
-	lru "github.com/hashicorp/golang-lru/v2"
 )
 
 const dfltCache = 1024
 
-var (
-	exprCache *lru.Cache[string, *epl.Program]
-	typeCache *lru.Cache[typeKey, *validatedType]
-)
+// entityPath is the field path reported for errors which do not pertain to
+// any particular field of the validated value.
+const entityPath = "<entity>"
+
+var sharedCache *Cache
 
 var (
 	debug  = os.Getenv("VALIDATE_DEBUG") != ""
@@ -41,24 +42,58 @@ func sizeFromEnv(n string, d int) int {
 }
 
 func init() {
-	if size := sizeFromEnv("GO_VALIDATE_EXPR_CACHE_SIZE", dfltCache); size > 0 {
-		var err error
-		exprCache, err = lru.New[string, *epl.Program](size) // in practice this cannot fail because we've checked that size > 0
-		if err != nil {
-			panic(fmt.Errorf("validate: Could not create expression cache: %v", err))
-		}
-	}
-	if size := sizeFromEnv("GO_VALIDATE_TYPE_CACHE_SIZE", dfltCache); size > 0 {
-		var err error
-		typeCache, err = lru.New[typeKey, *validatedType](size) // in practice this cannot fail because we've checked that size > 0
-		if err != nil {
-			panic(fmt.Errorf("validate: Could not create type cache: %v", err))
-		}
-	}
+	sharedCache = NewCache(CacheConfig{
+		ExprSize:    sizeFromEnv("GO_VALIDATE_EXPR_CACHE_SIZE", dfltCache),
+		TypeSize:    sizeFromEnv("GO_VALIDATE_TYPE_CACHE_SIZE", dfltCache),
+		PatternSize: sizeFromEnv("GO_VALIDATE_PATTERN_CACHE_SIZE", dfltCache),
+	})
+}
+
+var buffers = sync.Pool{
+	New: func() interface{} {
+		return &errorBuffer{}
+	},
 }
 
 type errorBuffer struct {
 	E []error
+	W []error    // warnings, which do not cause validation to fail
+	T *tracer    // the tracer, if we are explaining validation
+	O Observer   // the observer, if any
+	P *pathStack // the path to the value being validated, shared with sub-buffers
+	G workers    // the goroutines available to validate concurrently, if any
+	p pathStack
+}
+
+// newErrorBuffer obtains an empty buffer from the pool which validates
+// beneath the provided path. The buffer must be released once its errors
+// have been consumed.
+func newErrorBuffer(base string, t *tracer, o Observer) *errorBuffer {
+	e := buffers.Get().(*errorBuffer)
+	e.T, e.O = t, o
+	e.p.base = base
+	e.P = &e.p
+	return e
+}
+
+// sub creates a new, empty buffer which shares the receiver's path, tracer
+// and observer.
+func (e *errorBuffer) sub() *errorBuffer {
+	s := buffers.Get().(*errorBuffer)
+	s.T, s.O, s.P, s.G = e.T, e.O, e.P, e.G
+	return s
+}
+
+// release returns the buffer to the pool. The error slices are handed off
+// to whoever consumed them, so only the path is reused.
+func (e *errorBuffer) release() {
+	*e = errorBuffer{p: pathStack{elems: e.p.elems[:0]}}
+	buffers.Put(e)
+}
+
+// path formats the path to the value being validated
+func (e *errorBuffer) path() string {
+	return e.P.String()
 }
 
 func (e *errorBuffer) Len() int {
@@ -69,50 +104,55 @@ func (e *errorBuffer) Add(v ...error) {
 	e.E = append(e.E, v...)
 }
 
+func (e *errorBuffer) Warn(v ...error) {
+	e.W = append(e.W, v...)
+}
+
 func keyPath(b, f string) string {
 	if b != "" {
-		return fmt.Sprintf("%s.%s", b, f)
+		return b + "." + f
 	} else {
 		return f
 	}
 }
 
 func indexPath(f string, n int) string {
-	return fmt.Sprintf("%s[%d]", f, n)
+	return f + "[" + strconv.Itoa(n) + "]"
 }
 
 func altsPath(b string, f []string) string {
-	return keyPath(b, fmt.Sprintf("{%s}", strings.Join(f, ",")))
+	return keyPath(b, "{"+strings.Join(f, ",")+"}")
 }
 
 type Context struct {
 	Path string
+	g    workers // the goroutines of the validation this context belongs to, if any
 }
 
 // WithPath returns a new context based on the receiver with the Path
 // field replaced by the provided value.
 func (c Context) WithPath(p string) Context {
-	return Context{Path: p}
+	return Context{Path: p, g: c.g}
 }
 
 // WithField returns a new context based on the receiver with the Path
 // field replaced by the current path with the provided field appended.
 func (c Context) WithField(f string) Context {
-	return Context{Path: keyPath(c.Path, f)}
+	return Context{Path: keyPath(c.Path, f), g: c.g}
 }
 
 // WithFieldAlts returns a new context based on the receiver with the Path
 // field replaced by the current path with the provided fields alternates
 // appended.
 func (c Context) WithFieldAlternates(f ...string) Context {
-	return Context{Path: altsPath(c.Path, f)}
+	return Context{Path: altsPath(c.Path, f), g: c.g}
 }
 
 // WithField returns a new context based on the receiver with the Path
 // field replaced by the current path with the provided index subscript
 // appended.
 func (c Context) WithIndex(v int) Context {
-	return Context{Path: indexPath(c.Path, v)}
+	return Context{Path: indexPath(c.Path, v), g: c.g}
 }
 
 // FieldError creates a new field error from this context and the provided
@@ -143,112 +183,259 @@ type IntrospectorV3 interface {
 	Validate(Validator, Context) (error, bool)
 }
 
+// Generated is implemented by types for which validation code has been
+// generated by validate-gen. The generated method validates the value
+// without reflection and reports whether it handled validation at all; it
+// declines when the validator's tags differ from those the code was
+// generated for, in which case the value is validated by reflection.
+type Generated interface {
+	ValidateGenerated(Validator, Context, *Result) (valid, handled bool)
+}
+
 var (
 	introspectorV1 = reflect.TypeOf((*IntrospectorV1)(nil)).Elem()
 	introspectorV2 = reflect.TypeOf((*IntrospectorV2)(nil)).Elem()
 	introspectorV3 = reflect.TypeOf((*IntrospectorV3)(nil)).Elem()
+	generated      = reflect.TypeOf((*Generated)(nil)).Elem()
 )
 
 type Validator struct {
-	checkTag, errTag, nameTag, basePath string
+	checkTag, errTag, nameTag, sevTag, depTag, basePath string
+	onDeprecated                                        DeprecationHandler
+	logger                                              *slog.Logger
+	observer                                            Observer
+	unsupported                                         UnsupportedPolicy
+	kindHandlers                                        map[reflect.Kind]KindHandler
+	cache                                               *Cache
+	reflective                                          bool
+	concurrency                                         int
 }
 
 func New(opts ...Option) Validator {
 	return NewWithConfig(Config{
-		CheckTag: "check",
-		ErrorTag: "invalid",
-		FieldTag: "json",
-		BasePath: "",
+		CheckTag:      "check",
+		ErrorTag:      "invalid",
+		FieldTag:      "json",
+		SeverityTag:   "severity",
+		DeprecatedTag: "deprecated",
+		BasePath:      "",
 	}.WithOptions(opts))
 }
 
 func NewWithConfig(conf Config) Validator {
 	return Validator{
-		checkTag: conf.CheckTag,
-		errTag:   conf.ErrorTag,
-		nameTag:  conf.FieldTag,
-		basePath: conf.BasePath,
+		checkTag:     conf.CheckTag,
+		errTag:       conf.ErrorTag,
+		nameTag:      conf.FieldTag,
+		sevTag:       conf.SeverityTag,
+		depTag:       conf.DeprecatedTag,
+		basePath:     conf.BasePath,
+		onDeprecated: conf.OnDeprecated,
+		logger:       conf.Logger,
+		observer:     conf.Observer,
+		unsupported:  conf.Unsupported,
+		kindHandlers: conf.KindHandlers,
+		cache:        conf.Cache,
+		reflective:   conf.Reflective,
+		concurrency:  conf.Concurrency,
 	}
 }
 
 func (v Validator) WithOptions(opts ...Option) Validator {
-	return NewWithConfig(Config{
-		CheckTag: v.checkTag,
-		ErrorTag: v.errTag,
-		FieldTag: v.nameTag,
-		BasePath: v.basePath,
-	}.WithOptions(opts))
+	return NewWithConfig(v.config().WithOptions(opts))
+}
+
+func (v Validator) config() Config {
+	return Config{
+		CheckTag:      v.checkTag,
+		ErrorTag:      v.errTag,
+		FieldTag:      v.nameTag,
+		SeverityTag:   v.sevTag,
+		DeprecatedTag: v.depTag,
+		BasePath:      v.basePath,
+		OnDeprecated:  v.onDeprecated,
+		Logger:        v.logger,
+		Observer:      v.observer,
+		Unsupported:   v.unsupported,
+		KindHandlers:  v.kindHandlers,
+		Cache:         v.cache,
+		Reflective:    v.reflective,
+		Concurrency:   v.concurrency,
+	}
+}
+
+// Tags returns the names of the tags this validator considers.
+func (v Validator) Tags() Tags {
+	return Tags{
+		Check:      v.checkTag,
+		Error:      v.errTag,
+		Field:      v.nameTag,
+		Severity:   v.sevTag,
+		Deprecated: v.depTag,
+	}
+}
+
+// log returns the logger used for diagnostics, which is the default
+// logger unless one has been configured.
+func (v Validator) log() *slog.Logger {
+	if v.logger != nil {
+		return v.logger
+	} else {
+		return slog.Default()
+	}
 }
 
+// Validate validates the provided value and returns the errors that were
+// found, if any. Warnings are not reported; use [Validator.Evaluate] to
+// obtain them as well.
 func (v Validator) Validate(s interface{}) Errors {
-	errs := &errorBuffer{}
-	v.validate(v.basePath, reflect.ValueOf(s), errs)
-	return errs.E
+	return v.Evaluate(s).Errors
+}
+
+// Evaluate validates the provided value and returns both the errors and
+// the warnings that were found. Warnings are produced by checks on fields
+// with a severity of "warn" and do not make the value invalid.
+func (v Validator) Evaluate(s interface{}) Result {
+	if debug {
+		t := v.Explain(s)
+		v.log().Info("validate: Trace", "trace", t) // debugging has been explicitly requested, so this isn't logged at the debug level
+		return t.Result
+	}
+	return v.run(s, nil)
 }
 
-func (v Validator) validate(p string, s reflect.Value, errs *errorBuffer) bool {
+func (v Validator) run(s interface{}, t *tracer) Result {
+	var start time.Time
+	errs := newErrorBuffer(v.basePath, t, v.observer)
+	defer errs.release()
+	if t == nil {
+		errs.G = newWorkers(v.concurrency) // traces are sequential
+	}
+	typ := reflect.TypeOf(s)
+	if errs.O != nil {
+		start = time.Now()
+		errs.O.OnValidateStart(typ)
+	}
+	v.validate(reflect.ValueOf(s), errs)
+	res := Result{
+		Errors:   errs.E,
+		Warnings: errs.W,
+	}
+	if errs.O != nil {
+		errs.O.OnValidateEnd(typ, res, time.Since(start))
+	}
+	return res
+}
+
+// Check validates a value beneath the provided context and adds the
+// errors and warnings it produces to the result. It is used by generated
+// code to validate the values it does not handle itself. Values checked
+// beneath a context provided by the validator share the goroutines of the
+// validation it belongs to.
+func (v Validator) Check(c Context, s interface{}, res *Result) bool {
+	errs := newErrorBuffer(c.Path, nil, v.observer)
+	defer errs.release()
+	if errs.G = c.g; errs.G == nil {
+		errs.G = newWorkers(v.concurrency) // checked from the top level
+	}
+	valid := v.validate(reflect.ValueOf(s), errs)
+	res.Errors = append(res.Errors, errs.E...)
+	res.Warnings = append(res.Warnings, errs.W...)
+	return valid
+}
+
+func (v Validator) validate(s reflect.Value, errs *errorBuffer) bool {
 	s = reflect.Indirect(s)
 	t := s.Type()
+	if valid, ok := v.validateGenerated(s, errs); ok {
+		return valid
+	}
 	switch {
 	case t.Implements(introspectorV3):
-		return v.validateIntrospectorV3(p, s, errs)
+		return v.validateIntrospectorV3(s, errs)
 	case t.Implements(introspectorV2):
-		return v.validateIntrospectorV2(p, s, errs)
+		return v.validateIntrospectorV2(s, errs)
 	case t.Implements(introspectorV1):
-		return v.validateIntrospectorV1(p, s, errs)
+		return v.validateIntrospectorV1(s, errs)
 	default:
-		return v.validateFields(p, s, errs)
+		return v.validateFields(s, errs)
 	}
 }
 
-func (v Validator) validateIntrospectorV1(p string, s reflect.Value, errs *errorBuffer) bool {
+// validateGenerated validates a value using its generated implementation,
+// if it has one. Generated code does not report the checks it performs,
+// so it is not used while validation is being explained or observed.
+func (v Validator) validateGenerated(s reflect.Value, errs *errorBuffer) (bool, bool) {
+	if v.reflective || errs.T != nil || errs.O != nil || !s.CanInterface() || !s.Type().Implements(generated) {
+		return false, false
+	}
+	var res Result
+	valid, ok := s.Interface().(Generated).ValidateGenerated(v, Context{Path: errs.path(), g: errs.G}, &res)
+	if ok {
+		errs.Add(res.Errors...)
+		errs.Warn(res.Warnings...)
+	}
+	return valid, ok
+}
+
+func (v Validator) validateIntrospectorV1(s reflect.Value, errs *errorBuffer) bool {
+	st := errs.begin("", s, true)
 	r := s.MethodByName("Validate").Call([]reflect.Value{})
-	if err := unwrapError(r[0]); err != nil {
-		errs.Add(fieldErrors(p, err)...)
+	err := unwrapError(r[0])
+	errs.end(st, err == nil)
+	if err != nil {
+		errs.Add(fieldErrors(errs.path(), err)...)
 		return false
 	}
 	return true
 }
 
-func (v Validator) validateIntrospectorV2(p string, s reflect.Value, errs *errorBuffer) bool {
+func (v Validator) validateIntrospectorV2(s reflect.Value, errs *errorBuffer) bool {
 	var valid bool
+	st := errs.begin("", s, true)
 	r := s.MethodByName("Validate").Call([]reflect.Value{reflect.ValueOf(v)})
-	if err := unwrapError(r[0]); err != nil {
-		errs.Add(fieldErrors(p, err)...)
+	err := unwrapError(r[0])
+	errs.end(st, err == nil)
+	if err != nil {
+		errs.Add(fieldErrors(errs.path(), err)...)
 	} else {
 		valid = true
 	}
 	if r[1].Bool() {
-		return v.validateFields(p, s, errs) && valid
+		return v.validateFields(s, errs) && valid
 	} else {
 		return valid
 	}
 }
 
-func (v Validator) validateIntrospectorV3(p string, s reflect.Value, errs *errorBuffer) bool {
+func (v Validator) validateIntrospectorV3(s reflect.Value, errs *errorBuffer) bool {
 	var valid bool
-	c := Context{Path: p}
+	p := errs.path()
+	c := Context{Path: p, g: errs.G}
+	st := errs.begin("", s, true)
 	r := s.MethodByName("Validate").Call([]reflect.Value{reflect.ValueOf(v), reflect.ValueOf(c)})
-	if err := unwrapError(r[0]); err != nil {
+	err := unwrapError(r[0])
+	errs.end(st, err == nil)
+	if err != nil {
 		errs.Add(fieldErrors(p, err)...)
 	} else {
 		valid = true
 	}
 	if r[1].Bool() {
-		return v.validateFields(p, s, errs) && valid
+		return v.validateFields(s, errs) && valid
 	} else {
 		return valid
 	}
 }
 
-func (v Validator) validateFields(p string, s reflect.Value, errs *errorBuffer) bool {
+func (v Validator) validateFields(s reflect.Value, errs *errorBuffer) bool {
 	switch s.Kind() {
 	case reflect.Interface, reflect.Pointer:
-		return v.validateFields(p, s.Elem(), errs)
+		return v.validateFields(s.Elem(), errs)
 	case reflect.Struct:
-		return v.validateStruct(p, s, errs)
+		return v.validateStruct(s, errs)
 	case reflect.Slice, reflect.Array:
-		return v.validateSlice(p, s, errs)
+		return v.validateSlice(s, errs)
 	case // primitive is always valid when it's not a field, except through introspection
 		reflect.Invalid,
 		reflect.Bool,
@@ -259,159 +446,241 @@ func (v Validator) validateFields(p string, s reflect.Value, errs *errorBuffer)
 		reflect.String:
 		return true
 	default: // anything else cannot be validated, to varying degress of concern
-		if strict {
-			panic(fmt.Errorf("validate: Unsupported type: %v", s.Type())) // this is a configuration error in strict mode
+		return v.validateUnsupported(s, errs)
+	}
+}
+
+func (v Validator) validateUnsupported(s reflect.Value, errs *errorBuffer) bool {
+	if h, ok := v.kindHandlers[s.Kind()]; ok {
+		p := errs.path()
+		if err := h(v, Context{Path: p, g: errs.G}, s); err != nil {
+			errs.Add(fieldErrors(p, err)...)
+			return false
 		}
-		fmt.Printf("validate: [%s] ignoring unsupported type: %s\n", p, s.Type().Name())
+		return true
+	}
+	switch v.unsupported.resolve() {
+	case UnsupportedIgnore:
+		return true
+	case UnsupportedReport:
+		errs.Add(FieldErrorf(coalesce(errs.path(), entityPath), "Unsupported type: %v", s.Type()))
+		return false
+	case UnsupportedPanic:
+		panic(fmt.Errorf("validate: Unsupported type: %v", s.Type())) // this is a configuration error in strict mode
+	default:
+		v.log().Warn("validate: Ignoring unsupported type", "path", errs.path(), "type", s.Type().String())
 		return true // we don't support this type, so just ignore it
 	}
 }
 
-func (v Validator) validateSlice(p string, s reflect.Value, errs *errorBuffer) bool {
-	valid, l := true, s.Len()
-	for i := 0; i < l; i++ {
-		if !v.validate(fmt.Sprintf("%s[%d]", p, i), s.Index(i), errs) {
-			valid = false
-		}
+func (v Validator) validateSlice(s reflect.Value, errs *errorBuffer) bool {
+	if l := s.Len(); errs.G != nil && l >= 2*minChunk {
+		return v.validateSliceConcurrently(s, errs)
+	} else {
+		return v.validateElems(s, 0, l, errs)
 	}
-	return valid
 }
 
-func (v Validator) validateStruct(p string, s reflect.Value, errs *errorBuffer) bool {
-	typ := s.Type()
-	tkey := newTypeKey(typ, v)
+// validateElems validates the elements of a slice in the range [lo, hi)
+func (v Validator) validateElems(s reflect.Value, lo, hi int, errs *errorBuffer) bool {
+	valid := true
+	for i := lo; i < hi; i++ {
+		elem := s.Index(i)
+		errs.P.pushIndex(i)
+		st := errs.begin("", elem, false)
+		ok := v.validate(elem, errs)
+		errs.end(st, ok)
+		errs.P.pop()
+		valid = ok && valid
+	}
+	return valid
+}
 
-	var vt *validatedType
-	if typeCache != nil {
-		if v, ok := typeCache.Get(tkey); ok {
-			vt = v
-		}
+func (v Validator) validateStruct(s reflect.Value, errs *errorBuffer) bool {
+	vt, err := v.validatedType(s.Type(), errs)
+	if err != nil {
+		panic(fmt.Errorf("validate: %v", err)) // this is a configuration error
 	}
-	if vt == nil {
-		vt = newType(typ, v)
-		if typeCache != nil {
-			typeCache.Add(tkey, vt)
-		}
+
+	if errs.G != nil && vt.Subtrees > 1 {
+		return v.validateStructConcurrently(s, vt, errs)
 	}
 
+	en := acquireEnv(v, s)
+	defer en.release()
+
 	valid := true
 	for _, e := range vt.Fields {
-		f := s.Field(e.Index)
-		path := keyPath(p, e.Name)
-
-		// recurse to embedded fields unless they are explicitly skipped via
-		// the check above: embed:"" or embed:"-"
-		if e.Field.Anonymous {
-			// we don't allow introspection on embedded fields, this has already been
-			// done on the containing struct since it inherits embedded methods and
-			// therefore embedded interface conformance
-			valid = v.validateFields(path, f, errs) && valid
-			continue
-		}
+		valid = v.validateStructField(s, e, en, errs) && valid
+	}
 
-		switch e.Expr {
-		case "check":
-			valid = v.validate(path, f, errs) && valid
-		default:
-			if !f.CanInterface() {
-				panic(fmt.Errorf("validate: Cannot validate unexported field: [%s] %v", e.Name, e.Field))
-			}
-			val := f.Interface()
+	return valid
+}
 
-			var expr *epl.Program
-			if exprCache != nil {
-				if v, ok := exprCache.Get(e.Expr); ok {
-					expr = v
-				}
-			}
+func (v Validator) validateStructField(s reflect.Value, e validatedField, en *env, errs *errorBuffer) bool {
+	errs.P.pushKey(e.Name)
+	defer errs.P.pop()
+	if e.Deprecated {
+		v.checkDeprecated(s, e, errs)
+	}
+	switch {
+	case e.Unchecked:
+		return true
+	case e.Severity == SeverityWarning:
+		// a failed warning does not invalidate the value; anything reported
+		// while checking the field, including by sub-validations, is
+		// reported as a warning instead of an error
+		sub := errs.sub()
+		v.validateField(s, e, en, sub)
+		errs.Warn(sub.E...)
+		errs.Warn(sub.W...)
+		sub.release()
+		return true
+	default:
+		return v.validateField(s, e, en, errs)
+	}
+}
 
-			if expr == nil {
-				var err error
-				expr, err = epl.Compile(e.Expr)
-				if err != nil {
-					panic(fmt.Errorf("validate: Could not compile expression: %v", err)) // this is a configuration error
-				}
-				if exprCache != nil {
-					exprCache.Add(e.Expr, expr)
-				}
-			}
+// checkDeprecated reports a warning and notifies the deprecation handler,
+// if any, when a deprecated field is set to a non-zero value.
+func (v Validator) checkDeprecated(s reflect.Value, e validatedField, errs *errorBuffer) {
+	if s.Field(e.Index).IsZero() {
+		return
+	}
+	path := errs.path()
+	if e.Deprecation != "" {
+		errs.Warn(FieldErrorf(path, "Deprecated: %s", e.Deprecation))
+	} else {
+		errs.Warn(FieldErrorf(path, "Deprecated"))
+	}
+	if v.onDeprecated != nil {
+		v.onDeprecated(Deprecation{
+			Type:    s.Type(),
+			Field:   e.Field.Name,
+			Path:    path,
+			Message: e.Deprecation,
+		})
+	}
+}
 
-			check := func(x interface{}) bool {
-				return v.validate(path, reflect.ValueOf(x), errs)
-			}
-			date := func(y, m, d float64) time.Time {
-				return time.Date(int(y), time.Month(m), int(d), 0, 0, 0, 0, time.UTC)
-			}
-			cxt := map[string]interface{}{
-				"self":  val,
-				"len":   v.len,
-				"now":   time.Now,
-				"date":  date,
-				"check": check,
-				"str":   stdlib.Strings{},
-			}
-			if s.CanInterface() {
-				v := s.Interface()
-				cxt["super"] = v
-				cxt["sup"] = v
-			}
+func (v Validator) validateField(s reflect.Value, e validatedField, en *env, errs *errorBuffer) bool {
+	f := s.Field(e.Index)
 
-			res, err := expr.Exec(cxt)
-			if err != nil {
-				panic(fmt.Errorf("validate: Could not evaluate expression: %v", err)) // this is a configuration error
-			}
+	// recurse to embedded fields unless they are explicitly skipped via
+	// the check above: embed:"" or embed:"-"
+	if e.Field.Anonymous {
+		// we don't allow introspection on embedded fields, this has already been
+		// done on the containing struct since it inherits embedded methods and
+		// therefore embedded interface conformance
+		return v.validateFields(f, errs)
+	}
 
-			if res != nil {
-				switch c := res.(type) {
-				case nil: // no error
-				case error:
-					if c != nil {
-						if !e.Noerr {
-							errs.Add(c)
-						}
-						valid = false
-					}
-				case []error:
-					if len(c) > 0 {
-						if !e.Noerr {
-							errs.Add(c...)
-						}
-						valid = false
-					}
-				case bool:
-					if !c {
-						if !e.Noerr {
-							if e.Message != "" {
-								errs.Add(&FieldError{Field: path, Message: e.Message})
-							} else {
-								errs.Add(FieldErrorf(path, "Constraint not satisfied: %s", e.Expr))
-							}
-						}
-						valid = false
-					}
-				default:
-					if !e.Noerr {
-						errs.Add(FieldErrorf(path, "Invalid expression result: %T (expected %T) in %v", res, []error{}, res))
+	st := errs.begin(e.Expr, f, false)
+	valid := v.checkField(f, e, en, errs)
+	errs.end(st, valid)
+	return valid
+}
+
+func (v Validator) checkField(f reflect.Value, e validatedField, en *env, errs *errorBuffer) bool {
+	if e.Expr == "check" {
+		return v.validate(f, errs)
+	}
+
+	if !f.CanInterface() {
+		panic(fmt.Errorf("validate: Cannot validate unexported field: [%s] %v", e.Name, e.Field))
+	}
+
+	expr, err := v.compile(e.Expr, errs)
+	if err != nil {
+		panic(fmt.Errorf("validate: Could not compile expression: %v", err)) // this is a configuration error
+	}
+
+	en.self, en.errs = f.Interface(), errs
+	res, err := expr.Exec(en)
+	en.self, en.errs = nil, nil
+	if err != nil {
+		panic(fmt.Errorf("validate: Could not evaluate expression: %v", err)) // this is a configuration error
+	}
+
+	valid := true
+	if res != nil {
+		switch c := res.(type) {
+		case nil: // no error
+		case error:
+			if c != nil {
+				if !e.Noerr {
+					errs.Add(c)
+				}
+				valid = false
+			}
+		case []error:
+			if len(c) > 0 {
+				if !e.Noerr {
+					errs.Add(c...)
+				}
+				valid = false
+			}
+		case bool:
+			if !c {
+				if !e.Noerr {
+					if e.Message != "" {
+						errs.Add(&FieldError{Field: errs.path(), Message: e.Message})
+					} else {
+						errs.Add(FieldErrorf(errs.path(), "Constraint not satisfied: %s", e.Expr))
 					}
-					valid = false
 				}
+				valid = false
+			}
+		default:
+			if !e.Noerr {
+				errs.Add(FieldErrorf(errs.path(), "Invalid expression result: %T (expected %T) in %v", res, []error{}, res))
 			}
+			valid = false
 		}
 	}
 
 	return valid
 }
 
-func (v Validator) len(s interface{}) int {
-	z := reflect.ValueOf(s)
-	switch z.Kind() {
-	case reflect.Invalid:
-		return 0
-	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-		return z.Len()
-	default:
-		panic(fmt.Errorf("validate: Type does not have a length: %T", s))
+// validatedType obtains the description of how to validate the provided
+// struct type, from the cache if possible.
+func (v Validator) validatedType(t reflect.Type, errs *errorBuffer) (*validatedType, error) {
+	c := v.Cache()
+	key := newTypeKey(t, v)
+	if c.types != nil {
+		vt, ok := c.types.Get(key)
+		errs.cached(TypeCache, ok)
+		if ok {
+			return vt, nil
+		}
+	}
+	v.log().Debug("validate: Type cache miss", "type", t.String())
+	vt, err := newType(t, v)
+	if err != nil {
+		return nil, err
+	}
+	c.types.Add(key, vt)
+	return vt, nil
+}
+
+// compile obtains the compiled program for the provided expression source,
+// from the cache if possible.
+func (v Validator) compile(src string, errs *errorBuffer) (*epl.Program, error) {
+	c := v.Cache()
+	if c.exprs != nil {
+		prog, ok := c.exprs.Get(src)
+		errs.cached(ExprCache, ok)
+		if ok {
+			return prog, nil
+		}
+	}
+	v.log().Debug("validate: Expression cache miss", "expr", src)
+	prog, err := epl.Compile(src)
+	if err != nil {
+		return nil, err
 	}
+	c.exprs.Add(src, prog)
+	return prog, nil
 }
 
 func unwrapError(val reflect.Value) error {
@@ -447,7 +716,7 @@ func fieldErrors(p string, err error) []error {
 		return []error{fielderr}
 	}
 	return []error{
-		newFieldError(coalesce(p, "<entity>"), err),
+		newFieldError(coalesce(p, entityPath), err),
 	}
 }
 
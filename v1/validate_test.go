@@ -1,12 +1,16 @@
 package validate
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/bww/epl/v1"
-	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/bww/go-validate/v1/stdlib"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -274,9 +278,7 @@ func TestMode(t *testing.T) {
 }
 
 func BenchmarkValidateWithCache(b *testing.B) {
-	exprCache, _ = lru.New[string, *epl.Program](dfltCache)
-	typeCache, _ = lru.New[typeKey, *validatedType](dfltCache)
-	v := New()
+	v := New(PrivateCache(CacheConfig{ExprSize: dfltCache, TypeSize: dfltCache}))
 	for i := 0; i < b.N; i++ {
 		v.Validate(testA{})
 		v.Validate(testA{"A"})
@@ -291,9 +293,7 @@ func BenchmarkValidateWithCache(b *testing.B) {
 }
 
 func BenchmarkValidateWithoutCache(b *testing.B) {
-	exprCache = nil
-	typeCache = nil
-	v := New()
+	v := New(DisableCache())
 	for i := 0; i < b.N; i++ {
 		v.Validate(testA{})
 		v.Validate(testA{"A"})
@@ -306,3 +306,224 @@ func BenchmarkValidateWithoutCache(b *testing.B) {
 		v.Validate(testC{1, -1, 0, 1, 1})
 	}
 }
+
+type benchA struct {
+	Name  string   `json:"name" check:"len(self) > 0"`
+	Count int      `json:"count" check:"self >= 0 && self < 100"`
+	Code  string   `json:"code" check:"str.AlphaNumeric(self)"`
+	Items []benchB `json:"items" check:"check(self)"`
+	Owner *benchB  `json:"owner" check:"self == nil || check(self)"`
+}
+
+type benchB struct {
+	ID    string `json:"id" check:"len(self) > 0"`
+	Limit int    `json:"limit" check:"self <= sup.Max"`
+	Max   int    `json:"max"`
+}
+
+// BenchmarkValidateValid measures the common case of validating a value
+// which is valid, which should allocate very little.
+func BenchmarkValidateValid(b *testing.B) {
+	v := New()
+	x := &benchA{
+		Name:  "Example",
+		Count: 10,
+		Code:  "ABC123",
+		Items: []benchB{{ID: "a", Limit: 1, Max: 2}, {ID: "b", Limit: 2, Max: 2}},
+		Owner: &benchB{ID: "c", Max: 1},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if errs := v.Validate(x); len(errs) > 0 {
+			b.Fatal(errs)
+		}
+	}
+}
+
+// BenchmarkValidateInvalid measures validating a value which is invalid,
+// for which paths must be materialized in order to report errors.
+func BenchmarkValidateInvalid(b *testing.B) {
+	v := New()
+	x := &benchA{
+		Count: 100,
+		Items: []benchB{{Limit: 1}},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if errs := v.Validate(x); len(errs) != 5 {
+			b.Fatal(errs)
+		}
+	}
+}
+
+type allocA struct {
+	B []allocB `json:"b" check:"check"`
+	C *allocB  `json:"c" check:"check"`
+}
+
+type allocB struct {
+	D allocC `json:"d" check:"check"`
+}
+
+type allocC struct {
+	E string `json:"e"`
+}
+
+func TestAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("pools are deliberately unreliable under the race detector")
+	}
+	// traversing a valid value allocates nothing; only evaluating
+	// expressions and reporting errors does
+	v := New(BasePath("root"))
+	x := &allocA{B: []allocB{{}, {}}, C: &allocB{}}
+	n := testing.AllocsPerRun(100, func() {
+		v.Validate(x)
+	})
+	assert.Equal(t, float64(0), n)
+}
+
+type collA struct {
+	Items []string `json:"items" check:"coll.MinLen(self, 1) && coll.MaxLen(self, 3)"`
+	Tags  []string `json:"tags" check:"coll.MaxLen(self, 1)" invalid:"Too many tags"`
+	Roles []string `json:"roles" check:"self == nil || coll.MinLen(self, 1)"`
+}
+
+func TestCollMessages(t *testing.T) {
+	checkValid(t, New(), collA{Items: []string{"a"}}, nil, nil)
+	checkValid(t, New(), collA{Tags: []string{"a", "b"}, Roles: []string{}}, []string{"items", "tags", "roles"}, []string{
+		"Must have between 1 and 3 items",
+		"Too many tags",
+		"Constraint not satisfied: self == nil || coll.MinLen(self, 1)",
+	})
+}
+
+// nsA is the struct in which namespace expressions are evaluated, for
+// those which refer to other fields
+type nsA struct {
+	Country string
+}
+
+// TestNamespaces checks that each namespace of the standard library is
+// available to expressions; the namespaces themselves are tested in stdlib.
+func TestNamespaces(t *testing.T) {
+	tests := []struct {
+		Expr   string
+		Self   interface{}
+		Expect bool
+	}{
+		{`str.Len(self) > 0`, "Ziggy", true},
+		{`str.Len(self) > 0`, "", false},
+		{`net.Email(self)`, "ziggy@example.com", true},
+		{`net.Email(self)`, "ziggy", false},
+		{`time.Zone(self)`, "Europe/London", true},
+		{`time.Zone(self)`, "Europe/Nowhere", false},
+		{`num.Compare(self, 0) > 0`, "12.50", true},
+		{`num.Compare(self, 0) > 0`, "0", false},
+		{`coll.Unique(self)`, []string{"a", "b"}, true},
+		{`coll.Unique(self)`, []string{"a", "a"}, false},
+		{`id.ULID(self)`, "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{`id.ULID(self)`, "", false},
+		{`fin.IBAN(self)`, "GB82WEST12345698765432", true},
+		{`fin.IBAN(self)`, "GB00WEST12345698765432", false},
+		{`iso.Country(self)`, "GB", true},
+		{`iso.Country(self)`, "UK", false},
+		{`sec.SafeFilename(self)`, "avatar.png", true},
+		{`sec.SafeFilename(self)`, "../avatar.png", false},
+		{`phone.Valid(self, sup.Country)`, "020 7946 0958", true},
+		{`phone.Valid(self, sup.Country)`, "", false},
+		{`postal.Valid(self, sup.Country)`, "SW1A 1AA", true},
+		{`postal.Valid(self, sup.Country)`, "", false},
+	}
+	v := New()
+	tested := make(map[string]bool)
+	for _, e := range tests {
+		tested[e.Expr[:strings.Index(e.Expr, ".")]] = true
+		prog, err := epl.Compile(e.Expr)
+		if !assert.NoError(t, err, e.Expr) {
+			continue
+		}
+		en := acquireEnv(v, reflect.ValueOf(nsA{Country: "GB"}))
+		en.self = e.Self
+		res, err := prog.Exec(en)
+		en.release()
+		if assert.NoError(t, err, e.Expr) {
+			assert.Equal(t, e.Expect, res, "%s: %v", e.Expr, e.Self)
+		}
+	}
+	for _, e := range stdlib.Names() {
+		assert.True(t, tested[e], "No expressions use the %s namespace", e)
+	}
+}
+
+type sevB struct {
+	F1 string `json:"b_1" check:"str.Match(\"@\", self) == false" severity:"warn" invalid:"Looks like an email address"`
+	F2 *testA `json:"b_2" check:"self == nil || check(self)" severity:"warn"`
+	F3 testA  `json:"b_3" check:"check(self)"`
+}
+
+type sevC struct {
+	F1 string `json:"c_1" check:"len(self) > 0" severity:"critical"`
+}
+
+func TestSeverity(t *testing.T) {
+	v := New()
+
+	res := v.Evaluate(sevB{F1: "Ziggy", F3: testA{"A"}})
+	assert.True(t, res.Valid())
+	assert.Len(t, res.Warnings, 0)
+
+	res = v.Evaluate(sevB{F1: "ziggy@example.com", F2: &testA{}, F3: testA{"A"}})
+	assert.True(t, res.Valid())
+	assert.Equal(t, []string{"b_1", "b_2.a_1", "b_2"}, res.Warnings.Fields())
+	assert.Equal(t, "Looks like an email address", res.Warnings.Messages()[0])
+	assert.Len(t, v.Validate(sevB{F1: "ziggy@example.com", F2: &testA{}, F3: testA{"A"}}), 0)
+
+	res = v.Evaluate(sevB{F1: "ziggy@example.com"})
+	assert.False(t, res.Valid())
+	assert.Equal(t, []string{"b_3.a_1", "b_3"}, res.Errors.Fields())
+	assert.Equal(t, []string{"b_1"}, res.Warnings.Fields())
+
+	assert.Panics(t, func() { v.Validate(sevC{}) })
+	assert.Equal(t, []string{"c_1"}, New(SeverityTag("")).Validate(sevC{}).Fields()) // the tag is not considered
+	assert.Equal(t, []string{"b_1"}, New(SeverityTag("")).Evaluate(sevB{F1: "ziggy@example.com", F3: testA{"A"}}).Errors.Fields())
+}
+
+type depA struct {
+	F1 string `json:"a_1" check:"len(self) > 0"`
+	F2 string `json:"a_2" deprecated:"use a_1 instead"`
+	F3 *testA `json:"a_3" check:"self == nil || check(self)" deprecated:""`
+	F4 int    `json:"a_4" check:"-" deprecated:"no longer supported"`
+}
+
+func TestDeprecated(t *testing.T) {
+	var deps []Deprecation
+	v := New(OnDeprecated(func(d Deprecation) {
+		deps = append(deps, d)
+	}))
+
+	res := v.Evaluate(depA{F1: "A"})
+	assert.True(t, res.Valid())
+	assert.Len(t, res.Warnings, 0)
+	assert.Len(t, deps, 0)
+
+	res = v.Evaluate(depA{F2: "B", F3: &testA{}, F4: 1})
+	assert.Equal(t, []string{"a_1", "a_3.a_1", "a_3"}, res.Errors.Fields())
+	assert.Equal(t, []string{"a_2", "a_3", "a_4"}, res.Warnings.Fields())
+	assert.Equal(t, []string{"Deprecated: use a_1 instead", "Deprecated", "Deprecated: no longer supported"}, res.Warnings.Messages())
+	if assert.Len(t, deps, 3) {
+		assert.Equal(t, Deprecation{Type: reflect.TypeOf(depA{}), Field: "F2", Path: "a_2", Message: "use a_1 instead"}, deps[0])
+	}
+
+	assert.Len(t, New(DeprecatedTag("")).Evaluate(depA{F1: "A", F2: "B"}).Warnings, 0)
+}
+
+func TestLogger(t *testing.T) {
+	type unsupported struct {
+		F1 chan int `json:"f_1" check:"check(self)"`
+	}
+	buf := &bytes.Buffer{}
+	v := New(Logger(slog.New(slog.NewTextHandler(buf, nil))))
+	assert.Len(t, v.Validate(unsupported{make(chan int)}), 0)
+	assert.Contains(t, buf.String(), `level=WARN msg="validate: Ignoring unsupported type" path=f_1 type="chan int"`)
+}
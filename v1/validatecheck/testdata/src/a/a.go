@@ -0,0 +1,36 @@
+package a
+
+type Valid struct {
+	A int      `json:"a" check:"self >= 0" invalid:"Must be >= zero"`
+	B string   `json:"b" check:"len(self) > 0 && self != sup.C"`
+	C string   `json:"c" check:"sup.Enabled() || len(self) == 0"`
+	D *Valid   `json:"d" check:"self == nil || check(self)" invalid:"-"`
+	E []string `json:"e" check:"check"`
+	F string   `json:"f" check:"str.Match(\"sup.Nope\", self)"`
+	g string   `check:"-"`
+	h string
+	Embedded
+}
+
+func (v Valid) Enabled() bool { return v.A > 0 }
+
+type Embedded struct {
+	Z int `json:"z" check:"self > sup.A"` // want `check expression refers to sup.A, which is not a field or method of Embedded`
+}
+
+type Invalid struct {
+	A int    `json:"a" check:"self >= "`                       // want `invalid check expression: .*`
+	B string `json:"b" check:"len(self) > 0 && self != sup.Q"` // want `check expression refers to sup.Q, which is not a field or method of Invalid`
+	C string `json:"c" check:"super.d == self"`                // want `check expression refers to sup.d, which is unexported and cannot be accessed`
+	d string `json:"d" check:"len(self) > 0"`                  // want `check tag on unexported field d cannot be evaluated`
+	E string `json:"e" check:"len(self) > 0" invalid:"Oops`    // want `malformed invalid tag: .*`
+	F string `json:"f" check:"len(self) > 0" invalid:""`       // want `empty invalid tag: .*`
+	G string `json:"g" check:len(self) > 0`                    // want `malformed check tag: .*`
+	H string `json:"h" check,other:"self != sup.Missing"`      // want `check expression refers to sup.Missing, .*`
+}
+
+func anonymous() interface{} {
+	return struct {
+		A int `check:"self > sup.B"` // want `check expression refers to sup.B, which is not a field or method of struct{A int "check:\\"self > sup.B\\""}`
+	}{}
+}
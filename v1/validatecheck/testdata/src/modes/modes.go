@@ -0,0 +1,7 @@
+package modes
+
+type Modes struct {
+	A string `json:"a" create:"len(self) > 0"`
+	B string `json:"b" create,update:"len(self) > "` // want `invalid create expression: .*` `invalid update expression: .*`
+	C string `json:"c" check:"this is not checked in modes"`
+}
@@ -0,0 +1,220 @@
+// Package validatecheck defines an analyzer which checks the validation
+// tags of struct fields, so that mistakes which would otherwise cause a
+// panic when a value is validated are found at build time.
+//
+// The analyzer reports:
+//   - expressions which cannot be compiled;
+//   - references to sup.X or super.X where X is neither a field nor a
+//     method of the enclosing struct, or is an unexported field;
+//   - checks on unexported fields, which cannot be evaluated;
+//   - check and error message tags which cannot be parsed, and empty error
+//     message tags.
+//
+// Check tags may be combined using the comma-delimited notation supported
+// by the validator, as in `create,update:"len(self) > 0"`.
+package validatecheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bww/epl/v1"
+	"github.com/bww/go-validate/v1/internal/tags"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check struct field validation tags
+
+The validatecheck analyzer compiles the expressions found in validation
+tags and reports expressions which cannot be compiled, references to
+fields of the enclosing struct that do not exist, checks on unexported
+fields and malformed error message tags.`
+
+// Analyzer checks validation tags using the default tag names.
+var Analyzer = New(Config{})
+
+// Config describes the tags the analyzer considers. Empty values are
+// replaced by the validator's defaults.
+type Config struct {
+	CheckTags []string // the names of tags which contain expressions; by default "check"
+	ErrorTag  string   // the name of the tag which contains error messages; by default "invalid"
+}
+
+// New creates an analyzer with the provided configuration. The tag names
+// may also be set by the flags -checktags and -errortag.
+func New(conf Config) *analysis.Analyzer {
+	c := &checker{
+		checkTags: strings.Join(conf.CheckTags, ","),
+		errorTag:  conf.ErrorTag,
+	}
+	if c.checkTags == "" {
+		c.checkTags = "check"
+	}
+	if c.errorTag == "" {
+		c.errorTag = "invalid"
+	}
+	a := &analysis.Analyzer{
+		Name:     "validatecheck",
+		Doc:      doc,
+		Run:      c.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+	a.Flags.StringVar(&c.checkTags, "checktags", c.checkTags, "comma-delimited names of tags which contain validation expressions")
+	a.Flags.StringVar(&c.errorTag, "errortag", c.errorTag, "the name of the tag which contains error messages")
+	return a
+}
+
+type checker struct {
+	checkTags string
+	errorTag  string
+}
+
+// supRef matches references to members of the enclosing struct
+var supRef = regexp.MustCompile(`\bsup(?:er)?\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// strLit matches string literals in expressions
+var strLit = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+func (c *checker) run(pass *analysis.Pass) (interface{}, error) {
+	names := strings.Split(c.checkTags, ",")
+	for i, e := range names {
+		names[i] = strings.TrimSpace(e)
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.StructType)(nil)}
+	insp.Preorder(filter, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		tv, ok := pass.TypesInfo.Types[st]
+		if !ok {
+			return
+		}
+		enclosing := enclosingType(pass, st, tv.Type)
+		for _, f := range st.Fields.List {
+			if f.Tag != nil {
+				c.checkField(pass, names, enclosing, f)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// enclosingType returns the named type declared by the struct, if there is
+// one, so that its methods are considered; otherwise the struct type.
+func enclosingType(pass *analysis.Pass, st *ast.StructType, t types.Type) types.Type {
+	for _, f := range pass.Files {
+		if f.Pos() > st.Pos() || st.End() > f.End() {
+			continue
+		}
+		var named types.Type
+		ast.Inspect(f, func(n ast.Node) bool {
+			if named != nil {
+				return false
+			}
+			if ts, ok := n.(*ast.TypeSpec); ok && ts.Type == st {
+				if obj := pass.TypesInfo.Defs[ts.Name]; obj != nil {
+					named = obj.Type()
+				}
+				return false
+			}
+			return true
+		})
+		if named != nil {
+			return named
+		}
+	}
+	return t
+}
+
+func (c *checker) checkField(pass *analysis.Pass, names []string, enclosing types.Type, f *ast.Field) {
+	raw, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return // the compiler will have something to say about this
+	}
+
+	entries, err := tags.Parse(raw)
+	if serr, ok := err.(*tags.SyntaxError); ok {
+		for _, n := range serr.Names {
+			if n == c.errorTag || contains(names, n) {
+				pass.Reportf(f.Tag.Pos(), "malformed %s tag: %v", n, serr)
+				break
+			}
+		}
+	}
+
+	for _, e := range entries {
+		if e.Has(c.errorTag) && strings.TrimSpace(e.Value) == "" {
+			pass.Reportf(f.Tag.Pos(), "empty %s tag: omit the tag to use the default message or use \"-\" to suppress it", c.errorTag)
+		}
+	}
+
+	for _, name := range names {
+		for _, e := range entries {
+			if e.Has(name) {
+				c.checkExpr(pass, name, strings.TrimSpace(e.Value), enclosing, f)
+				break
+			}
+		}
+	}
+}
+
+func (c *checker) checkExpr(pass *analysis.Pass, tag, src string, enclosing types.Type, f *ast.Field) {
+	if src == "" || src == "-" {
+		return
+	}
+
+	if len(f.Names) > 0 { // embedded fields are validated recursively, not via an expression
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				pass.Reportf(n.Pos(), "%s tag on unexported field %s cannot be evaluated", tag, n.Name)
+			}
+		}
+	}
+
+	if src == "check" {
+		return
+	}
+	if _, err := epl.Compile(src); err != nil {
+		pass.Reportf(f.Tag.Pos(), "invalid %s expression: %s", tag, firstLine(err.Error()))
+		return
+	}
+
+	for _, m := range supRef.FindAllStringSubmatch(strLit.ReplaceAllString(src, `""`), -1) {
+		checkMember(pass, f.Tag.Pos(), tag, enclosing, m[1])
+	}
+}
+
+func checkMember(pass *analysis.Pass, pos token.Pos, tag string, t types.Type, name string) {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, pass.Pkg, name)
+	if obj == nil {
+		// unexported members of other packages are not found by lookup from
+		// this package, but they are equally inaccessible at runtime
+		pass.Reportf(pos, "%s expression refers to sup.%s, which is not a field or method of %s", tag, name, types.TypeString(t, types.RelativeTo(pass.Pkg)))
+		return
+	}
+	if !obj.Exported() {
+		pass.Reportf(pos, "%s expression refers to sup.%s, which is unexported and cannot be accessed", tag, name)
+	}
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func firstLine(s string) string {
+	if x := strings.IndexByte(s, '\n'); x >= 0 {
+		return s[:x]
+	}
+	return s
+}
@@ -0,0 +1,15 @@
+package validatecheck
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestAnalyzerModes(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), New(Config{CheckTags: []string{"create", "update"}}), "modes")
+}
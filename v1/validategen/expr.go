@@ -0,0 +1,468 @@
+package validategen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The expression parser implements the subset of EPL which can be
+// translated to Go. It follows EPL's grammar exactly, including its
+// right-associative operators, so that a translated expression is
+// evaluated the same way the interpreter would evaluate it. Anything it
+// does not understand is reported as unsupported and the type is left to
+// be validated by reflection.
+
+// node is an expression tree node
+type node interface {
+	String() string
+}
+
+type identNode struct {
+	Name string
+}
+
+func (n *identNode) String() string {
+	return n.Name
+}
+
+// literalNode is a literal; its value is a float64, a string, a bool or nil
+type literalNode struct {
+	Value interface{}
+}
+
+func (n *literalNode) String() string {
+	switch v := n.Value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type binaryNode struct {
+	Op          string
+	Left, Right node
+}
+
+func (n *binaryNode) String() string {
+	return fmt.Sprintf("(%v %s %v)", n.Left, n.Op, n.Right)
+}
+
+// derefNode evaluates Right with the value of Left pushed onto the
+// context, so that identifiers are first resolved as its members.
+type derefNode struct {
+	Left, Right node
+}
+
+func (n *derefNode) String() string {
+	return fmt.Sprintf("%v.%v", n.Left, n.Right)
+}
+
+// invokeNode invokes the method Name of Left or, when Left is nil, the
+// function Name found in the context.
+type invokeNode struct {
+	Left node
+	Name string
+	Args []node
+}
+
+func (n *invokeNode) String() string {
+	args := make([]string, len(n.Args))
+	for i, e := range n.Args {
+		args[i] = e.String()
+	}
+	if n.Left != nil {
+		return fmt.Sprintf("[%v]%s(%s)", n.Left, n.Name, strings.Join(args, ", "))
+	} else {
+		return fmt.Sprintf("%s(%s)", n.Name, strings.Join(args, ", "))
+	}
+}
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenTrue
+	tokenFalse
+	tokenNil
+	tokenOp
+)
+
+type lexeme struct {
+	Type  tokenType
+	Text  string      // the operator or identifier
+	Value interface{} // the value of a literal
+}
+
+func (t lexeme) String() string {
+	switch t.Type {
+	case tokenEOF:
+		return "end of input"
+	case tokenNumber, tokenString:
+		return fmt.Sprintf("%v", t.Value)
+	default:
+		return t.Text
+	}
+}
+
+// unsupportedError describes an expression which cannot be translated
+type unsupportedError struct {
+	Reason string
+}
+
+func (e *unsupportedError) Error() string {
+	return e.Reason
+}
+
+func unsupportedf(f string, a ...interface{}) error {
+	return &unsupportedError{fmt.Sprintf(f, a...)}
+}
+
+// scan tokenizes an expression the way EPL does
+func scan(src string) ([]lexeme, error) {
+	var toks []lexeme
+	for i := 0; i < len(src); {
+		r, w := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += w
+		case r == '"':
+			v, n, err := scanString(src[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, lexeme{Type: tokenString, Value: v})
+			i += n + 1
+		case isDigit(r):
+			v, n, err := scanNumber(src[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, lexeme{Type: tokenNumber, Value: v})
+			i += n
+		case r == '_' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'):
+			n := w
+			for n < len(src) {
+				c, z := utf8.DecodeRuneInString(src[i+n:])
+				if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+				n += z
+			}
+			id := src[i : i+n]
+			switch {
+			case (r == 'u' || r == 'U') && strings.HasPrefix(src[i+1:], ":"):
+				return nil, unsupportedf("UUID literals are not supported")
+			case id == "true":
+				toks = append(toks, lexeme{Type: tokenTrue, Text: id})
+			case id == "false":
+				toks = append(toks, lexeme{Type: tokenFalse, Text: id})
+			case id == "nil":
+				toks = append(toks, lexeme{Type: tokenNil, Text: id})
+			default:
+				toks = append(toks, lexeme{Type: tokenIdent, Text: id})
+			}
+			i += n
+		case (r == '+' || r == '-') && i+1 < len(src) && isDigit(rune(src[i+1])):
+			// like EPL, a sign which immediately precedes a digit is part of the number
+			v, n, err := scanNumber(src[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, lexeme{Type: tokenNumber, Value: v})
+			i += n
+		default:
+			op := string(r)
+			if i+1 < len(src) {
+				switch two := src[i : i+2]; two {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					op = two
+				}
+			}
+			switch op {
+			case "(", ")", ".", ",", "+", "-", "*", "/", "%", "<", ">", "&&", "||", "==", "!=", "<=", ">=":
+				toks = append(toks, lexeme{Type: tokenOp, Text: op})
+				i += len(op)
+			default: // including subscripts, which are not supported
+				return nil, unsupportedf("Unsupported lexeme: %q", op)
+			}
+		}
+	}
+	return append(toks, lexeme{Type: tokenEOF}), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// scanString scans a string literal whose opening quote has already been
+// consumed, returning its value and the number of bytes consumed.
+func scanString(src string) (string, int, error) {
+	b := &strings.Builder{}
+	for i := 0; i < len(src); {
+		r, w := utf8.DecodeRuneInString(src[i:])
+		i += w
+		switch r {
+		case '"':
+			return b.String(), i, nil
+		case '\\':
+			if i >= len(src) {
+				return "", 0, fmt.Errorf("Unterminated string")
+			}
+			e := src[i]
+			i++
+			switch e {
+			case 'a':
+				b.WriteRune('\a')
+			case 'b':
+				b.WriteRune('\b')
+			case 'f':
+				b.WriteRune('\f')
+			case 'n':
+				b.WriteRune('\n')
+			case 'r':
+				b.WriteRune('\r')
+			case 't':
+				b.WriteRune('\t')
+			case 'v':
+				b.WriteRune('\v')
+			case '\\', '"':
+				b.WriteByte(e)
+			case 'x', 'u', 'U':
+				n := map[byte]int{'x': 2, 'u': 4, 'U': 8}[e]
+				if i+n > len(src) {
+					return "", 0, fmt.Errorf("Invalid escape sequence")
+				}
+				v, err := strconv.ParseUint(src[i:i+n], 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("Invalid escape sequence")
+				}
+				b.WriteString(string(rune(v)))
+				i += n
+			default: // octal escapes are not handled consistently by EPL
+				return "", 0, unsupportedf("Unsupported escape sequence: \\%c", e)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", 0, fmt.Errorf("Unterminated string")
+}
+
+// scanNumber scans a number, which may be preceded by a sign, returning
+// its value and the number of bytes consumed.
+func scanNumber(src string) (float64, int, error) {
+	i := 0
+	if src[i] == '+' || src[i] == '-' {
+		i++
+	}
+	start := i
+	for i < len(src) && isDigit(rune(src[i])) {
+		i++
+	}
+	if src[start] == '0' && i-start > 1 || i < len(src) && (src[i] == 'x' || src[i] == 'X') {
+		return 0, 0, unsupportedf("Octal and hexadecimal numbers are not supported")
+	}
+	float := false
+	if i < len(src) && src[i] == '.' {
+		float = true
+		i++
+		for i < len(src) && isDigit(rune(src[i])) {
+			i++
+		}
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		float = true
+		i++
+		if i < len(src) && (src[i] == '+' || src[i] == '-') {
+			i++
+		}
+		for i < len(src) && isDigit(rune(src[i])) {
+			i++
+		}
+	}
+	if float {
+		v, err := strconv.ParseFloat(src[:i], 64)
+		return v, i, err
+	}
+	v, err := strconv.ParseInt(src[:i], 10, 64)
+	return float64(v), i, err
+}
+
+// parse parses an expression
+func parse(src string) (node, error) {
+	toks, err := scan(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.Type != tokenEOF {
+		return nil, fmt.Errorf("Syntax error: %v", t)
+	}
+	return n, nil
+}
+
+type parser struct {
+	toks []lexeme
+	pos  int
+}
+
+func (p *parser) peek() lexeme {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() lexeme {
+	t := p.toks[p.pos]
+	if t.Type != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// peekOp returns the operator at the current position if it is one of
+// the provided operators.
+func (p *parser) peekOp(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.Type != tokenOp {
+		return "", false
+	}
+	for _, e := range ops {
+		if t.Text == e {
+			return e, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseExpr() (node, error) {
+	return p.parseBinary(0)
+}
+
+// levels lists binary operators by increasing precedence
+var levels = [][]string{
+	{"||"},
+	{"&&"},
+	{"<", ">", "==", "<=", ">=", "!="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses binary operators at the provided level of precedence.
+// As in EPL, the right operand is parsed at the same level, which makes
+// every operator right-associative.
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(levels) {
+		return p.parseDeref(nil)
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.peekOp(levels[level]...)
+	if !ok {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseBinary(level)
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{Op: op, Left: left, Right: right}, nil
+}
+
+func (p *parser) parseDeref(prev node) (node, error) {
+	left, err := p.parseInvoke(prev)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.peekOp("."); !ok {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseDeref(left)
+	if err != nil {
+		return nil, err
+	}
+	switch right.(type) {
+	case *identNode, *derefNode, *invokeNode:
+		return &derefNode{Left: left, Right: right}, nil
+	default:
+		return nil, fmt.Errorf("Expected ident, deref or subscript: %v", right)
+	}
+}
+
+func (p *parser) parseInvoke(left node) (node, error) {
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.peekOp("("); !ok {
+		return right, nil
+	}
+	p.next()
+	var args []node
+	if _, ok := p.peekOp(")"); !ok {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.peekOp(","); !ok {
+				break
+			}
+			p.next()
+		}
+	}
+	if _, ok := p.peekOp(")"); !ok {
+		return nil, fmt.Errorf("Expected ')' but found %v", p.peek())
+	}
+	p.next()
+	id, ok := right.(*identNode)
+	if !ok {
+		return nil, unsupportedf("Cannot invoke %v", right)
+	}
+	return &invokeNode{Left: left, Name: id.Name, Args: args}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.Type {
+	case tokenEOF:
+		return nil, fmt.Errorf("Unexpected end-of-input")
+	case tokenIdent:
+		return &identNode{Name: t.Text}, nil
+	case tokenNumber, tokenString:
+		return &literalNode{Value: t.Value}, nil
+	case tokenTrue:
+		return &literalNode{Value: true}, nil
+	case tokenFalse:
+		return &literalNode{Value: false}, nil
+	case tokenNil:
+		return &literalNode{Value: nil}, nil
+	}
+	if t.Text != "(" {
+		return nil, fmt.Errorf("Illegal lexeme in primary expression: %v", t)
+	}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.peekOp(")"); !ok {
+		return nil, fmt.Errorf("Expected ')' but found %v", p.peek())
+	}
+	p.next()
+	return n, nil
+}
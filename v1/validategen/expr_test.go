@@ -0,0 +1,45 @@
+package validategen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Expr   string
+		Expect string
+		Error  bool
+	}{
+		{`self`, `self`, false},
+		{`self > 0`, `(self > 0)`, false},
+		{`a - b - c`, `(a - (b - c))`, false},
+		{`a < b == c`, `(a < (b == c))`, false},
+		{`a || b && c`, `(a || (b && c))`, false},
+		{`(a || b) && c`, `((a || b) && c)`, false},
+		{`a * b + c % d`, `((a * b) + (c % d))`, false},
+		{`sup.A.B`, `sup.A.B`, false},
+		{`self.After(now())`, `self.[self]After(now())`, false},
+		{`len(self) == 0 || str.Match("^[a-z]+\n$", self)`, `((len(self) == 0) || str.[str]Match("^[a-z]+\n$", self))`, false},
+		{`self == nil || self != true`, `((self == nil) || (self != true))`, false},
+		{`self >= -1.5e3`, `(self >= -1500)`, false},
+		{`"\x41é\""`, `"Aé\""`, false},
+		{`self -1`, ``, true},
+		{`self[0]`, ``, true},
+		{`self = 1`, ``, true},
+		{`0x1f`, ``, true},
+		{`010`, ``, true},
+		{`"\101"`, ``, true},
+		{`len(self`, ``, true},
+		{`"open`, ``, true},
+	}
+	for _, e := range tests {
+		n, err := parse(e.Expr)
+		if e.Error {
+			assert.Error(t, err, e.Expr)
+		} else if assert.NoError(t, err, e.Expr) {
+			assert.Equal(t, e.Expect, n.String(), e.Expr)
+		}
+	}
+}
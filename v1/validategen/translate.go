@@ -0,0 +1,538 @@
+package validategen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bww/go-validate/v1/stdlib"
+)
+
+// operand is the result of translating an expression: the Go expression
+// which computes it and the type of the value EPL would observe.
+type operand struct {
+	Expr    string
+	Type    types.Type // nil for nil and builtin functions
+	Const   *float64   // the value of a numeric constant
+	Nil     bool       // the operand is the literal nil
+	Builtin string     // the name of the builtin function the operand refers to
+}
+
+// frame is an entry in the stack against which identifiers are resolved;
+// a nil frame is the validator's environment.
+type frame *operand
+
+// translator translates the expression of a single field
+type translator struct {
+	pkg     *types.Package
+	self    operand         // the field
+	sup     operand         // the struct
+	path    string          // the Go expression which produces the field's context
+	time    types.Type      // time.Time
+	stdlib  *types.Package  // the package which implements namespaces like str
+	imports map[string]bool // the packages the translated expression uses
+}
+
+var (
+	boolType    = types.Typ[types.Bool]
+	stringType  = types.Typ[types.String]
+	float64Type = types.Typ[types.Float64]
+	int64Type   = types.Typ[types.Int64]
+	errorType   = types.Universe.Lookup("error").Type()
+	emptyType   = types.NewInterfaceType(nil, nil)
+)
+
+// translate translates an expression which must produce a bool
+func (t *translator) translate(n node) (string, error) {
+	o, err := t.eval(n, []frame{nil})
+	if err != nil {
+		return "", err
+	}
+	if o.Type == nil || !types.Identical(o.Type, boolType) {
+		return "", unsupportedf("Expression does not produce a bool")
+	}
+	return o.Expr, nil
+}
+
+func (t *translator) eval(n node, frames []frame) (operand, error) {
+	switch c := n.(type) {
+	case *literalNode:
+		return literal(c.Value), nil
+	case *identNode:
+		return t.lookup(frames, c.Name, false)
+	case *binaryNode:
+		return t.binary(c, frames)
+	case *derefNode:
+		l, err := t.eval(c.Left, frames)
+		if err != nil {
+			return operand{}, err
+		}
+		frames = append(frames[:len(frames):len(frames)], &l)
+		if id, ok := c.Right.(*identNode); ok {
+			return t.lookup(frames, id.Name, false)
+		}
+		return t.eval(c.Right, frames)
+	case *invokeNode:
+		return t.invoke(c, frames)
+	default:
+		return operand{}, unsupportedf("Unsupported expression: %v", n)
+	}
+}
+
+func literal(v interface{}) operand {
+	switch c := v.(type) {
+	case nil:
+		return operand{Expr: "nil", Nil: true}
+	case float64:
+		return constant(c, float64Type)
+	case string:
+		return operand{Expr: strconv.Quote(c), Type: stringType}
+	case bool:
+		return operand{Expr: strconv.FormatBool(c), Type: boolType}
+	default:
+		panic(fmt.Errorf("validategen: Unexpected literal: %T", v))
+	}
+}
+
+func constant(v float64, t types.Type) operand {
+	return operand{Expr: fmt.Sprintf("%s(%s)", t, formatFloat(v)), Type: t, Const: &v}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// lookup resolves an identifier against the frame stack the way EPL does:
+// from the top of the stack down, skipping frames which do not define the
+// identifier. Methods are invoked unless value is set, in which case the
+// method value is produced.
+func (t *translator) lookup(frames []frame, name string, value bool) (operand, error) {
+	for i := len(frames) - 1; i >= 0; i-- {
+		f := frames[i]
+		if f == nil {
+			return t.lookupEnv(name)
+		}
+		o, ok, err := t.lookupMember(*f, name, value)
+		if err != nil {
+			return operand{}, err
+		}
+		if ok {
+			return o, nil
+		}
+	}
+	return operand{}, unsupportedf("Undefined: %s", name)
+}
+
+func (t *translator) lookupEnv(name string) (operand, error) {
+	switch name {
+	case "self":
+		return t.self, nil
+	case "sup", "super":
+		return t.sup, nil
+	case "len", "now", "date", "check":
+		return operand{Builtin: name}, nil
+	}
+	if ns, ok := stdlib.Namespace(name); ok {
+		n := reflect.TypeOf(ns).Name()
+		if obj, ok := t.stdlib.Scope().Lookup(n).(*types.TypeName); ok {
+			if n == "Strings" { // str compiles patterns by way of the validator's cache
+				return operand{Expr: "v.Strings()", Type: obj.Type()}, nil
+			}
+			t.imports["stdlib"] = true
+			return operand{Expr: "stdlib." + n + "{}", Type: obj.Type()}, nil
+		}
+	}
+	return operand{}, unsupportedf("Undefined or unsupported: %s", name)
+}
+
+// lookupMember resolves a member of a value the way EPL does: a method of
+// the value, then a field of the struct it refers to.
+func (t *translator) lookupMember(f operand, name string, value bool) (operand, bool, error) {
+	if f.Type == nil || !isStruct(f.Type) {
+		return operand{}, false, unsupportedf("Cannot dereference %s", f.Expr)
+	}
+	mset := types.NewMethodSet(f.Type)
+	if mset.Lookup(nil, "Variable") != nil {
+		return operand{}, false, unsupportedf("Cannot dereference %s, which may be an EPL context", f.Expr)
+	}
+	if !token.IsExported(name) {
+		if hasField(f.Type, name, map[types.Type]bool{}) {
+			return operand{}, false, unsupportedf("Cannot access unexported field %s of %s", name, f.Expr)
+		}
+		return operand{}, false, nil
+	}
+	if sel := mset.Lookup(nil, name); sel != nil {
+		sig := sel.Type().(*types.Signature)
+		if value {
+			return operand{Expr: f.Expr + "." + name, Type: sig}, true, nil
+		}
+		o, err := t.call(f.Expr+"."+name, sig, nil, nil)
+		return o, true, err
+	}
+	obj, _, _ := types.LookupFieldOrMethod(f.Type, false, t.pkg, name)
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		return operand{Expr: f.Expr + "." + name, Type: v.Type()}, true, nil
+	}
+	return operand{}, false, nil
+}
+
+// isStruct determines if a type is a struct or a pointer to one
+func isStruct(t types.Type) bool {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// hasField determines if a struct, or any struct it embeds, has a field
+// with the provided name.
+func hasField(t types.Type, name string, seen map[types.Type]bool) bool {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if f.Name() == name || (f.Embedded() && hasField(f.Type(), name, seen)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *translator) invoke(n *invokeNode, frames []frame) (operand, error) {
+	if n.Left != nil {
+		recv, err := t.eval(n.Left, frames)
+		if err != nil {
+			return operand{}, err
+		}
+		if recv.Type == nil || types.IsInterface(recv.Type) {
+			return operand{}, unsupportedf("Cannot invoke %s on %s", n.Name, recv.Expr)
+		}
+		var sel *types.Selection
+		if token.IsExported(n.Name) {
+			sel = types.NewMethodSet(recv.Type).Lookup(nil, n.Name)
+		}
+		if sel == nil {
+			return operand{}, unsupportedf("No such method %s of %s", n.Name, recv.Expr)
+		}
+		return t.call(recv.Expr+"."+n.Name, sel.Type().(*types.Signature), n.Args, frames)
+	}
+	f, err := t.lookup(frames, n.Name, true)
+	if err != nil {
+		return operand{}, err
+	}
+	if f.Builtin != "" {
+		return t.builtin(f.Builtin, n.Args, frames)
+	}
+	if f.Type != nil {
+		if sig, ok := f.Type.Underlying().(*types.Signature); ok {
+			return t.call(f.Expr, sig, n.Args, frames)
+		}
+	}
+	return operand{}, unsupportedf("%s is not a function", n.Name)
+}
+
+// call produces a call of a function with the provided signature. Only
+// functions which produce a single value other than an error are
+// supported, since EPL treats errors as a failure to evaluate.
+func (t *translator) call(fn string, sig *types.Signature, args []node, frames []frame) (operand, error) {
+	if sig.Variadic() {
+		return operand{}, unsupportedf("Variadic functions are not supported: %s", fn)
+	}
+	if sig.Params().Len() != len(args) {
+		return operand{}, unsupportedf("%s takes %d arguments but is given %d", fn, sig.Params().Len(), len(args))
+	}
+	if sig.Results().Len() != 1 || types.Identical(sig.Results().At(0).Type(), errorType) {
+		return operand{}, unsupportedf("%s must produce a single value which is not an error", fn)
+	}
+	in := make([]string, len(args))
+	for i, e := range args {
+		a, err := t.eval(e, frames)
+		if err != nil {
+			return operand{}, err
+		}
+		in[i], err = assign(a, sig.Params().At(i).Type())
+		if err != nil {
+			return operand{}, err
+		}
+	}
+	return operand{Expr: fmt.Sprintf("%s(%s)", fn, strings.Join(in, ", ")), Type: sig.Results().At(0).Type()}, nil
+}
+
+// assign produces the expression for an argument passed as a parameter of
+// the provided type, following the rules EPL applies at runtime.
+func assign(a operand, t types.Type) (string, error) {
+	switch {
+	case a.Nil:
+		if isNillable(t) || types.IsInterface(t) {
+			return "nil", nil
+		}
+	case a.Type == nil:
+	case types.IsInterface(a.Type) && !types.IsInterface(t):
+	case a.Const != nil && types.Identical(t, a.Type):
+		return formatFloat(*a.Const), nil
+	case types.AssignableTo(a.Type, t):
+		return a.Expr, nil
+	}
+	return "", unsupportedf("Cannot use %s as %v", a.Expr, t)
+}
+
+func (t *translator) builtin(name string, args []node, frames []frame) (operand, error) {
+	in := make([]operand, len(args))
+	for i, e := range args {
+		a, err := t.eval(e, frames)
+		if err != nil {
+			return operand{}, err
+		}
+		if a.Nil || a.Builtin != "" {
+			return operand{}, unsupportedf("Unsupported argument to %s: %v", name, e)
+		}
+		in[i] = a
+	}
+	switch name {
+	case "len":
+		if len(in) != 1 {
+			break
+		}
+		switch in[0].Type.Underlying().(type) {
+		case *types.Basic:
+			if !types.Identical(in[0].Type.Underlying(), stringType) {
+				return operand{}, unsupportedf("%s does not have a length", in[0].Expr)
+			}
+		case *types.Slice, *types.Map, *types.Array, *types.Chan:
+		default:
+			return operand{}, unsupportedf("%s does not have a length", in[0].Expr)
+		}
+		return operand{Expr: fmt.Sprintf("len(%s)", in[0].Expr), Type: types.Typ[types.Int]}, nil
+	case "now":
+		if len(in) != 0 {
+			break
+		}
+		t.imports["time"] = true
+		return operand{Expr: "time.Now()", Type: t.time}, nil
+	case "date":
+		if len(in) != 3 {
+			break
+		}
+		var p [3]string
+		for i, e := range in {
+			if !types.AssignableTo(e.Type, float64Type) {
+				return operand{}, unsupportedf("Cannot use %s as %v", e.Expr, float64Type)
+			}
+			if e.Const != nil {
+				if *e.Const < math.MinInt32 || *e.Const > math.MaxInt32 {
+					return operand{}, unsupportedf("Date component out of range: %v", *e.Const)
+				}
+				p[i] = strconv.Itoa(int(*e.Const))
+			} else {
+				p[i] = fmt.Sprintf("int(%s)", e.Expr)
+			}
+		}
+		t.imports["time"] = true
+		return operand{Expr: fmt.Sprintf("time.Date(%s, time.Month(%s), %s, 0, 0, 0, 0, time.UTC)", p[0], p[1], p[2]), Type: t.time}, nil
+	case "check":
+		if len(in) != 1 {
+			break
+		}
+		x, err := assign(in[0], emptyType)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{Expr: fmt.Sprintf("v.Check(%s, %s, r)", t.path, x), Type: boolType}, nil
+	}
+	return operand{}, unsupportedf("Wrong number of arguments to %s", name)
+}
+
+func (t *translator) binary(n *binaryNode, frames []frame) (operand, error) {
+	l, err := t.eval(n.Left, frames)
+	if err != nil {
+		return operand{}, err
+	}
+	r, err := t.eval(n.Right, frames)
+	if err != nil {
+		return operand{}, err
+	}
+	switch n.Op {
+	case "||", "&&":
+		lb, err := truth(l)
+		if err != nil {
+			return operand{}, err
+		}
+		rb, err := truth(r)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{Expr: fmt.Sprintf("(%s %s %s)", lb, n.Op, rb), Type: boolType}, nil
+	case "==", "!=":
+		return equal(l, r, n.Op)
+	case "<", ">", "<=", ">=":
+		if !isNumeric(l) || !isNumeric(r) {
+			return operand{}, unsupportedf("Cannot compare %s and %s", l.Expr, r.Expr)
+		}
+		if l.Const != nil && r.Const != nil {
+			return literal(compare(*l.Const, *r.Const, n.Op)), nil
+		}
+		return operand{Expr: fmt.Sprintf("(%s %s %s)", num(l), n.Op, num(r)), Type: boolType}, nil
+	case "+":
+		if l.Type != nil && types.Identical(l.Type, stringType) {
+			if r.Type == nil || !types.Identical(r.Type, stringType) {
+				return operand{}, unsupportedf("Cannot concatenate %s and %s", l.Expr, r.Expr)
+			}
+			return operand{Expr: fmt.Sprintf("(%s + %s)", l.Expr, r.Expr), Type: stringType}, nil
+		}
+		fallthrough
+	case "-", "*", "/", "%":
+		return arithmetic(l, r, n.Op)
+	default:
+		return operand{}, unsupportedf("Unsupported operator: %s", n.Op)
+	}
+}
+
+// truth converts an operand to a bool the way EPL does
+func truth(o operand) (string, error) {
+	switch {
+	case o.Const != nil:
+		return strconv.FormatBool(*o.Const != 0), nil
+	case isNumeric(o):
+		return fmt.Sprintf("(%s != 0)", num(o)), nil
+	case o.Type != nil && types.Identical(o.Type, boolType):
+		return o.Expr, nil
+	case o.Type != nil && types.Identical(o.Type.Underlying(), boolType):
+		return fmt.Sprintf("bool(%s)", o.Expr), nil
+	default:
+		return "", unsupportedf("Cannot convert %s to bool", o.Expr)
+	}
+}
+
+// equal compares operands the way EPL does: numbers are compared by value
+// regardless of their types, nil is equal to any nil value, and anything
+// else is compared only when the types are identical.
+func equal(l, r operand, op string) (operand, error) {
+	var expr string
+	switch {
+	case l.Nil && r.Nil:
+		return literal(op == "=="), nil
+	case l.Nil && isNillable(r.Type):
+		expr = fmt.Sprintf("(%s %s nil)", r.Expr, op)
+	case r.Nil && isNillable(l.Type):
+		expr = fmt.Sprintf("(%s %s nil)", l.Expr, op)
+	case isNumeric(l) && isNumeric(r):
+		if l.Const != nil && r.Const != nil {
+			return literal((*l.Const == *r.Const) == (op == "==")), nil
+		}
+		expr = fmt.Sprintf("(%s %s %s)", num(l), op, num(r))
+	case l.Type != nil && r.Type != nil && types.Identical(l.Type, r.Type) && types.Comparable(l.Type) && !types.IsInterface(l.Type):
+		expr = fmt.Sprintf("(%s %s %s)", l.Expr, op, r.Expr)
+	default:
+		return operand{}, unsupportedf("Cannot compare %s and %s", l.Expr, r.Expr)
+	}
+	return operand{Expr: expr, Type: boolType}, nil
+}
+
+func compare(l, r float64, op string) bool {
+	switch op {
+	case "<":
+		return l < r
+	case ">":
+		return l > r
+	case "<=":
+		return l <= r
+	default:
+		return l >= r
+	}
+}
+
+// arithmetic performs arithmetic the way EPL does: in float64, except for
+// the remainder, which is computed in int64.
+func arithmetic(l, r operand, op string) (operand, error) {
+	if !isNumeric(l) || !isNumeric(r) {
+		return operand{}, unsupportedf("Cannot compute %s %s %s", l.Expr, op, r.Expr)
+	}
+	if op == "%" {
+		if r.Const != nil && int64(*r.Const) == 0 {
+			return operand{}, unsupportedf("Division by zero")
+		}
+		if l.Const != nil && r.Const != nil {
+			return constant(float64(int64(*l.Const)%int64(*r.Const)), int64Type), nil
+		}
+		return operand{Expr: fmt.Sprintf("(%s %% %s)", integer(l), integer(r)), Type: int64Type}, nil
+	}
+	if l.Const != nil && r.Const != nil {
+		var v float64
+		switch op {
+		case "+":
+			v = *l.Const + *r.Const
+		case "-":
+			v = *l.Const - *r.Const
+		case "*":
+			v = *l.Const * *r.Const
+		case "/":
+			v = *l.Const / *r.Const
+		}
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return operand{}, unsupportedf("Constant expression is not finite")
+		}
+		return constant(v, float64Type), nil
+	}
+	if op == "/" && r.Const != nil && *r.Const == 0 {
+		return operand{}, unsupportedf("Division by zero")
+	}
+	return operand{Expr: fmt.Sprintf("(%s %s %s)", num(l), op, num(r)), Type: float64Type}, nil
+}
+
+// num produces a float64 expression for a numeric operand
+func num(o operand) string {
+	switch {
+	case o.Const != nil:
+		return formatFloat(*o.Const)
+	case types.Identical(o.Type, float64Type):
+		return o.Expr
+	default:
+		return fmt.Sprintf("float64(%s)", o.Expr)
+	}
+}
+
+// integer produces an int64 expression for a numeric operand, truncating
+// it through float64 like EPL does.
+func integer(o operand) string {
+	switch {
+	case o.Const != nil:
+		return strconv.FormatInt(int64(*o.Const), 10)
+	default:
+		return fmt.Sprintf("int64(%s)", num(o))
+	}
+}
+
+// isNumeric determines if an operand is a number EPL can operate on
+func isNumeric(o operand) bool {
+	if o.Type == nil {
+		return false
+	}
+	b, ok := o.Type.Underlying().(*types.Basic)
+	if !ok || b.Kind() == types.Uintptr {
+		return false
+	}
+	return b.Info()&(types.IsInteger|types.IsFloat) != 0
+}
+
+func isNillable(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,102 @@
+package validategen
+
+import (
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/bww/go-validate/v1/stdlib"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadExternal loads the packages expressions may refer to
+func loadExternal(t *testing.T) map[string]*types.Package {
+	t.Helper()
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes,
+	}, "time", stdlibPath)
+	if !assert.NoError(t, err) || !assert.Len(t, pkgs, 2) {
+		t.FailNow()
+	}
+	ext := make(map[string]*types.Package)
+	for _, e := range pkgs {
+		ext[e.PkgPath] = e.Types
+	}
+	return ext
+}
+
+func TestTranslateNamespaces(t *testing.T) {
+	ext := loadExternal(t)
+	timeType := ext["time"].Scope().Lookup("Time").Type()
+	sup := types.NewStruct([]*types.Var{
+		types.NewField(0, nil, "F", stringType, false),
+		types.NewField(0, nil, "Country", stringType, false),
+	}, nil)
+	tests := []struct {
+		Self   types.Type
+		Expr   string
+		Expect string
+		Error  bool
+	}{
+		{stringType, `net.Email(self)`, `stdlib.Net{}.Email(s.F)`, false},
+		{stringType, `net.InCIDR(self, "10.0.0.0/8")`, `stdlib.Net{}.InCIDR(s.F, "10.0.0.0/8")`, false},
+		{int64Type, `net.Port(self)`, `stdlib.Net{}.Port(s.F)`, false},
+		{stringType, `net.PublicURL(self, "https")`, ``, true}, // variadic
+		{stringType, `time.Zone(self)`, `stdlib.Time{}.Zone(s.F)`, false},
+		{timeType, `time.Weekend(self) == false`, `(stdlib.Time{}.Weekend(s.F) == false)`, false},
+		{timeType, `time.Age(self) >= 18`, `(float64(stdlib.Time{}.Age(s.F)) >= 18)`, false},
+		{stringType, `num.Compare(self, 0) > 0`, `(float64(stdlib.Num{}.Compare(s.F, float64(0))) > 0)`, false},
+		{int64Type, `num.Between(self, 1, 10)`, `stdlib.Num{}.Between(s.F, float64(1), float64(10))`, false},
+		{stringType, `num.MaxDecimals(self, 2)`, `stdlib.Num{}.MaxDecimals(s.F, 2)`, false},
+		{types.NewSlice(stringType), `coll.Unique(self)`, `stdlib.Coll{}.Unique(s.F)`, false},
+		{types.NewSlice(stringType), `coll.MinLen(self, 1) && coll.MaxLen(self, 3)`, `(stdlib.Coll{}.MinLen(s.F, 1) && stdlib.Coll{}.MaxLen(s.F, 3))`, false},
+		{types.NewSlice(stringType), `coll.Contains(self, sup.Country)`, `stdlib.Coll{}.Contains(s.F, s.Country)`, false},
+		{stringType, `id.ULID(self)`, `stdlib.ID{}.ULID(s.F)`, false},
+		{stringType, `id.Satisfies(self, ">= 1.2.0")`, `stdlib.ID{}.Satisfies(s.F, ">= 1.2.0")`, false},
+		{stringType, `id.UUID(self, 4)`, ``, true}, // variadic
+		{stringType, `fin.IBAN(self)`, `stdlib.Fin{}.IBAN(s.F)`, false},
+		{float64Type, `fin.Amount(self, "EUR")`, `stdlib.Fin{}.Amount(s.F, "EUR")`, false},
+		{stringType, `iso.SubdivisionOf(self, sup.Country)`, `stdlib.ISO{}.SubdivisionOf(s.F, s.Country)`, false},
+		{stringType, `iso.Currency(self)`, `stdlib.ISO{}.Currency(s.F)`, false},
+		{stringType, `str.Len(self) > 0`, `(float64(v.Strings().Len(s.F)) > 0)`, false},
+		{stringType, `str.Trimmed(self) && str.Lower(self)`, `(v.Strings().Trimmed(s.F) && v.Strings().Lower(s.F))`, false},
+		{stringType, `str.HasPrefix(self, "+")`, `v.Strings().HasPrefix(s.F, "+")`, false},
+		{stringType, `str.Match("^[a-z]+$", self)`, `v.Strings().Match("^[a-z]+$", s.F)`, false},
+		{stringType, `str.Pattern("slug", self)`, `v.Strings().Pattern("slug", s.F)`, false},
+		{stringType, `sec.SafeFilename(self)`, `stdlib.Sec{}.SafeFilename(s.F)`, false},
+		{stringType, `sec.Classes(self) >= 3 && sec.Entropy(self) >= 40`, `((float64(stdlib.Sec{}.Classes(s.F)) >= 3) && (stdlib.Sec{}.Entropy(s.F) >= 40))`, false},
+		{stringType, `sec.Confusable(self) == false`, `(stdlib.Sec{}.Confusable(s.F) == false)`, false},
+		{stringType, `sec.Password(self, "strong")`, ``, true}, // variadic
+		{stringType, `phone.Valid(self, sup.Country)`, `stdlib.Phone{}.Valid(s.F, s.Country)`, false},
+		{stringType, `phone.E164(self)`, `stdlib.Phone{}.E164(s.F)`, false},
+		{stringType, `postal.Valid(self, sup.Country)`, `stdlib.Postal{}.Valid(s.F, s.Country)`, false},
+		{stringType, `postal.Valid(self, "GB")`, `stdlib.Postal{}.Valid(s.F, "GB")`, false},
+	}
+	tested := make(map[string]bool)
+	for _, e := range tests {
+		tested[e.Expr[:strings.Index(e.Expr, ".")]] = true
+		n, err := parse(e.Expr)
+		if !assert.NoError(t, err, e.Expr) {
+			continue
+		}
+		tr := &translator{
+			self:    operand{Expr: "s.F", Type: e.Self},
+			sup:     operand{Expr: "s", Type: sup},
+			path:    `c.WithField("f")`,
+			time:    timeType,
+			stdlib:  ext[stdlibPath],
+			imports: make(map[string]bool),
+		}
+		expr, err := tr.translate(n)
+		if e.Error {
+			assert.Error(t, err, e.Expr)
+		} else if assert.NoError(t, err, e.Expr) {
+			assert.Equal(t, e.Expect, expr, e.Expr)
+			assert.Equal(t, strings.Contains(e.Expect, "stdlib."), tr.imports["stdlib"], e.Expr)
+		}
+	}
+	for _, e := range stdlib.Names() {
+		assert.True(t, tested[e], "No expressions use the %s namespace", e)
+	}
+}
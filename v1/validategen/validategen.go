@@ -0,0 +1,351 @@
+// Package validategen generates code which validates struct types without
+// reflection. The generated code checks fields exactly as the validator
+// would, producing the same errors at the same paths, but evaluates
+// expressions as compiled Go instead of interpreting them.
+//
+// A type is supported when every expression among its checked fields can
+// be translated, which requires the types involved to be known statically.
+// Types which are not supported are skipped and continue to be validated
+// by reflection, as are types which:
+//   - implement an introspector, by having a Validate method;
+//   - have embedded fields which are checked;
+//   - have fields with a severity of warning, or which are deprecated;
+//   - have checked fields which are unexported.
+package validategen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bww/epl/v1"
+	"github.com/bww/go-validate/v1/internal/tags"
+	"github.com/bww/go-validate/v1/stdlib"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	validatePath = "github.com/bww/go-validate/v1"
+	stdlibPath   = validatePath + "/stdlib"
+)
+
+// Config describes what to generate. Empty values are replaced by the
+// validator's defaults.
+type Config struct {
+	Types    []string // the types to generate code for; by default every struct type with checked fields
+	Output   string   // the name of the generated file; by default <package>_validate.go
+	CheckTag string   // by default "check"
+	ErrorTag string   // by default "invalid"
+	FieldTag string   // by default "json"
+}
+
+func (c Config) withDefaults() Config {
+	if c.CheckTag == "" {
+		c.CheckTag = "check"
+	}
+	if c.ErrorTag == "" {
+		c.ErrorTag = "invalid"
+	}
+	if c.FieldTag == "" {
+		c.FieldTag = "json"
+	}
+	return c
+}
+
+// The generated code is only used by validators which consider the same
+// tags; these are not configurable since types which use them are not
+// supported.
+const (
+	severityTag   = "severity"
+	deprecatedTag = "deprecated"
+)
+
+// File is a generated source file
+type File struct {
+	Path    string   // the path the file should be written to
+	Source  []byte   // the formatted source
+	Types   []string // the types for which code was generated
+	Skipped []Skip   // the types which were skipped
+}
+
+// Skip describes a type for which code was not generated
+type Skip struct {
+	Type   string
+	Reason string
+}
+
+// Generate loads the package in the provided directory and generates code
+// for its types. Requesting a type explicitly which is not supported is an
+// error; other types which are not supported are skipped.
+func Generate(dir string, conf Config) (*File, error) {
+	conf = conf.withDefaults()
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes,
+		Dir:  dir,
+	}, ".", "time", stdlibPath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load package: %w", err)
+	}
+	if len(pkgs) != 3 {
+		return nil, fmt.Errorf("Expected a single package in %s", dir)
+	}
+	var errs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			errs = append(errs, e.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("Could not load package: %s", strings.Join(errs, "; "))
+	}
+
+	var pkg *packages.Package
+	ext := make(map[string]*types.Package)
+	for _, e := range pkgs {
+		switch e.PkgPath {
+		case "time", stdlibPath:
+			ext[e.PkgPath] = e.Types
+		default:
+			pkg = e
+		}
+	}
+	if pkg == nil || len(ext) != 2 {
+		return nil, fmt.Errorf("Could not load package in %s", dir)
+	}
+	if pkg.PkgPath == validatePath {
+		return nil, fmt.Errorf("Cannot generate code for the validate package itself")
+	}
+
+	g := &generator{
+		conf:    conf,
+		pkg:     pkg.Types,
+		time:    ext["time"].Scope().Lookup("Time").Type(),
+		stdlib:  ext[stdlibPath],
+		imports: make(map[string]bool),
+	}
+
+	names := conf.Types
+	explicit := len(names) > 0
+	if !explicit {
+		names = pkg.Types.Scope().Names()
+	}
+
+	file := &File{}
+	for _, name := range names {
+		obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			if explicit {
+				return nil, fmt.Errorf("%s is not a type", name)
+			}
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			if explicit {
+				return nil, fmt.Errorf("%s is not a struct type", name)
+			}
+			continue
+		}
+		checked, err := g.generate(named)
+		if err != nil {
+			if explicit {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			file.Skipped = append(file.Skipped, Skip{Type: name, Reason: err.Error()})
+			continue
+		}
+		if checked || explicit {
+			file.Types = append(file.Types, name)
+		}
+	}
+
+	src, err := g.source(file.Types)
+	if err != nil {
+		return nil, err
+	}
+	out := conf.Output
+	if out == "" {
+		out = pkg.Name + "_validate.go"
+	}
+	if !filepath.IsAbs(out) && len(pkg.GoFiles) > 0 {
+		out = filepath.Join(filepath.Dir(pkg.GoFiles[0]), out)
+	}
+	file.Path = out
+	file.Source = src
+	return file, nil
+}
+
+type generator struct {
+	conf    Config
+	pkg     *types.Package
+	time    types.Type
+	stdlib  *types.Package
+	imports map[string]bool
+	methods map[string][]byte
+}
+
+// generate generates the validation method for a type, reporting whether
+// the type has any checked fields.
+func (g *generator) generate(t *types.Named) (bool, error) {
+	if t.TypeParams().Len() > 0 {
+		return false, fmt.Errorf("Generic types are not supported")
+	}
+	for _, e := range []types.Type{t, types.NewPointer(t)} {
+		if types.NewMethodSet(e).Lookup(nil, "Validate") != nil {
+			return false, fmt.Errorf("Types which implement an introspector are not supported")
+		}
+	}
+
+	b := &bytes.Buffer{}
+	st := t.Underlying().(*types.Struct)
+	imports := make(map[string]bool)
+	checked := false
+	for i := 0; i < st.NumFields(); i++ {
+		x := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+
+		var name string
+		if v := tag.Get(g.conf.FieldTag); v != "" {
+			name = fieldName(v)
+		} else if !x.Embedded() {
+			name = x.Name()
+		}
+
+		if _, dep := tag.Lookup(deprecatedTag); dep {
+			return false, fmt.Errorf("%s: Deprecated fields are not supported", x.Name())
+		}
+		src, _ := tags.Find(tag, g.conf.CheckTag)
+		src = strings.TrimSpace(src)
+		if src == "-" || (src == "" && !x.Embedded()) {
+			continue
+		}
+		switch sev := tag.Get(severityTag); strings.ToLower(strings.TrimSpace(sev)) {
+		case "", "error":
+		default:
+			return false, fmt.Errorf("%s: Fields with a severity of %q are not supported", x.Name(), sev)
+		}
+		if x.Embedded() {
+			return false, fmt.Errorf("%s: Embedded fields are not supported", x.Name())
+		}
+		if !x.Exported() {
+			return false, fmt.Errorf("%s: Unexported fields cannot be validated", x.Name())
+		}
+		checked = true
+
+		path := fmt.Sprintf("c.WithField(%s)", strconv.Quote(name))
+		fmt.Fprintf(b, "\t// %s: %s\n", x.Name(), strings.Join(strings.Fields(src), " "))
+		if src == "check" {
+			if types.IsInterface(x.Type()) {
+				return false, fmt.Errorf("%s: Fields of interface types are not supported", x.Name())
+			}
+			fmt.Fprintf(b, "\tif !v.Check(%s, s.%s, r) {\n\t\tvalid = false\n\t}\n", path, x.Name())
+			continue
+		}
+
+		if _, err := epl.Compile(src); err != nil {
+			return false, fmt.Errorf("%s: Could not compile expression: %v", x.Name(), firstLine(err.Error()))
+		}
+		n, err := parse(src)
+		if err != nil {
+			return false, fmt.Errorf("%s: %v", x.Name(), err)
+		}
+		tr := &translator{
+			pkg:     g.pkg,
+			self:    operand{Expr: "s." + x.Name(), Type: x.Type()},
+			sup:     operand{Expr: "s", Type: t},
+			path:    path,
+			time:    g.time,
+			stdlib:  g.stdlib,
+			imports: imports,
+		}
+		expr, err := tr.translate(n)
+		if err != nil {
+			return false, fmt.Errorf("%s: %v", x.Name(), err)
+		}
+
+		fmt.Fprintf(b, "\tif !%s {\n", expr)
+		msg := strings.TrimSpace(tag.Get(g.conf.ErrorTag))
+		if msg == "" {
+			msg = stdlib.Message(src)
+		}
+		switch msg {
+		case "-": // errors are reported by a sub-validation
+		case "":
+			fmt.Fprintf(b, "\t\tr.Errors = append(r.Errors, &validate.FieldError{Field: %s.Path, Message: %s})\n", path, strconv.Quote("Constraint not satisfied: "+src))
+		default:
+			fmt.Fprintf(b, "\t\tr.Errors = append(r.Errors, &validate.FieldError{Field: %s.Path, Message: %s})\n", path, strconv.Quote(msg))
+		}
+		fmt.Fprintf(b, "\t\tvalid = false\n\t}\n")
+	}
+
+	for k := range imports {
+		g.imports[k] = true
+	}
+	if g.methods == nil {
+		g.methods = make(map[string][]byte)
+	}
+	g.methods[t.Obj().Name()] = b.Bytes()
+	return checked, nil
+}
+
+// source produces the formatted source of the generated file
+func (g *generator) source(names []string) ([]byte, error) {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// Code generated by validate-gen; DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name())
+
+	if len(names) > 0 {
+		std, ext := []string{}, []string{fmt.Sprintf("validate %q", validatePath)}
+		if g.imports["time"] {
+			std = append(std, `"time"`)
+		}
+		if g.imports["stdlib"] {
+			ext = append(ext, strconv.Quote(stdlibPath))
+		}
+		groups := []string{}
+		for _, e := range [][]string{std, ext} {
+			if len(e) > 0 {
+				groups = append(groups, "\t"+strings.Join(e, "\n\t"))
+			}
+		}
+		fmt.Fprintf(b, "import (\n%s\n)\n", strings.Join(groups, "\n\n"))
+	}
+
+	tags := fmt.Sprintf("validate.Tags{Check: %q, Error: %q, Field: %q, Severity: %q, Deprecated: %q}", g.conf.CheckTag, g.conf.ErrorTag, g.conf.FieldTag, severityTag, deprecatedTag)
+	for _, name := range names {
+		fmt.Fprintf(b, "\n// ValidateGenerated validates %s without reflection.\n", name)
+		fmt.Fprintf(b, "func (s %s) ValidateGenerated(v validate.Validator, c validate.Context, r *validate.Result) (bool, bool) {\n", name)
+		fmt.Fprintf(b, "\tif v.Tags() != (%s) {\n\t\treturn false, false\n\t}\n", tags)
+		fmt.Fprintf(b, "\tvalid := true\n")
+		b.Write(g.methods[name])
+		fmt.Fprintf(b, "\treturn valid, true\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Could not format generated code: %v", err)
+	}
+	return src, nil
+}
+
+func fieldName(t string) string {
+	if x := strings.Index(t, ","); x > 0 {
+		return t[:x]
+	} else {
+		return t
+	}
+}
+
+func firstLine(s string) string {
+	if x := strings.IndexByte(s, '\n'); x >= 0 {
+		return s[:x]
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+package validategen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const gentest = "../internal/gentest"
+
+func TestGenerate(t *testing.T) {
+	file, err := Generate(gentest, Config{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Account", "Owner"}, file.Types)
+	assert.Equal(t, []Skip{
+		{Type: "Dynamic", Reason: "Value: Cannot compare s.Value and nil"},
+		{Type: "Legacy", Reason: "Owner: Embedded fields are not supported"},
+		{Type: "Soft", Reason: "Nickname: Fields with a severity of \"warn\" are not supported"},
+	}, file.Skipped)
+
+	abs, err := filepath.Abs(filepath.Join(gentest, "gentest_validate.go"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, abs, file.Path)
+	}
+	expect, err := os.ReadFile(file.Path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(expect), string(file.Source), "generated code is out of date; run go generate")
+	}
+}
+
+func TestGenerateTypes(t *testing.T) {
+	file, err := Generate(gentest, Config{Types: []string{"Owner"}, Output: "owner.go", CheckTag: "create"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Owner"}, file.Types)
+		assert.Contains(t, string(file.Source), `validate.Tags{Check: "create", Error: "invalid", Field: "json"`)
+		assert.Equal(t, "owner.go", filepath.Base(file.Path))
+	}
+
+	_, err = Generate(gentest, Config{Types: []string{"Legacy"}})
+	assert.EqualError(t, err, "Legacy: Owner: Embedded fields are not supported")
+	_, err = Generate(gentest, Config{Types: []string{"Soft"}})
+	assert.EqualError(t, err, `Soft: Nickname: Fields with a severity of "warn" are not supported`)
+	_, err = Generate(gentest, Config{Types: []string{"Kind"}})
+	assert.EqualError(t, err, "Kind is not a struct type")
+}